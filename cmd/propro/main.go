@@ -1,10 +1,16 @@
 package main
 
 import (
-	"github.com/digitalstraw/propro/v2/pkg/analyzer"
-	"golang.org/x/tools/go/analysis/singlechecker"
+	"log"
+	"os"
+
+	"github.com/digitalstraw/propro/v2/pkg/cli"
 )
 
 func main() {
-	singlechecker.Main(analyzer.NewAnalyzer(map[string]any{}))
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(cli.Run(wd, os.Args[1:], os.Stdout, os.Stderr))
 }
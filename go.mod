@@ -2,10 +2,14 @@ module github.com/digitalstraw/propro/v2
 
 go 1.24.0
 
-require golang.org/x/tools v0.39.0
+require (
+	github.com/golangci/plugin-module-register v0.1.2
+	golang.org/x/mod v0.30.0
+	golang.org/x/tools v0.39.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/google/go-cmp v0.7.0 // indirect
-	golang.org/x/mod v0.30.0 // indirect
 	golang.org/x/sync v0.18.0 // indirect
 )
@@ -0,0 +1,263 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// aggregateDirective declares the aggregate root of a member struct: //propro:aggregate Order.
+const aggregateDirective = "aggregate"
+
+// mutatorFact marks methods of aggregate members which write fields of their receiver.
+type mutatorFact struct{}
+
+func (*mutatorFact) AFact() {}
+
+func (*mutatorFact) String() string {
+	return "propro mutator"
+}
+
+// validateAggregates checks that every member is listed under one aggregate root only.
+func validateAggregates(cfg *Config) error {
+	rootOf := map[string]string{}
+	for _, root := range slices.Sorted(maps.Keys(cfg.Aggregates)) {
+		for _, member := range cfg.Aggregates[root] {
+			if other, ok := rootOf[member]; ok && other != root {
+				return fmt.Errorf("%w: %s: %q is a member of both %s and %s", ErrInvalidConfigValue, aggregatesArg, member, other, root)
+			}
+			rootOf[member] = root
+		}
+	}
+	return nil
+}
+
+// exportMutatorFacts exports mutatorFact for methods of aggregate members declared in the analyzed package.
+// A method calling a mutating method on its receiver mutates it too, so the facts are propagated to a fixpoint.
+func (c *checker) exportMutatorFacts() {
+	methods := map[types.Object]*ast.FuncDecl{}
+	mutators := map[types.Object]bool{}
+	for _, file := range c.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || fn.Body == nil {
+				continue
+			}
+			named, ok := deref(c.TypesInfo.TypeOf(fn.Recv.List[0].Type)).(*types.Named)
+			if !ok || c.aggregateRoot(named) == "" {
+				continue
+			}
+			if obj := c.TypesInfo.Defs[fn.Name]; obj != nil {
+				methods[obj] = fn
+				mutators[obj] = c.writesReceiver(fn)
+			}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for obj, fn := range methods {
+			if !mutators[obj] && c.callsReceiverMutator(fn, mutators) {
+				mutators[obj] = true
+				changed = true
+			}
+		}
+	}
+	for obj, mutator := range mutators {
+		if mutator {
+			c.ExportObjectFact(obj, &mutatorFact{})
+		}
+	}
+}
+
+// callsReceiverMutator checks whether the method body calls a mutating method on its receiver or on its fields.
+// Methods of the analyzed package are looked up in mutators, those of dependencies by their facts.
+func (c *checker) callsReceiverMutator(fn *ast.FuncDecl, mutators map[types.Object]bool) bool {
+	recvObj := c.receiverObject(fn)
+	if recvObj == nil {
+		return false
+	}
+	calls := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if calls || !ok {
+			return !calls
+		}
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		selection, ok := c.TypesInfo.Selections[sel]
+		if !ok || selection.Kind() != types.MethodVal {
+			return true
+		}
+		root := rootIdent(sel.X)
+		if root != nil && c.TypesInfo.Uses[root] == recvObj {
+			calls = mutators[selection.Obj()] || c.ImportObjectFact(selection.Obj(), new(mutatorFact))
+		}
+		return !calls
+	})
+	return calls
+}
+
+// writesReceiver checks whether the method body writes fields of its receiver.
+func (c *checker) writesReceiver(fn *ast.FuncDecl) bool {
+	writes := false
+	c.inspectReceiverWrites(fn.Body, c.receiverObject(fn), func(ast.Expr) {
+		writes = true
+	})
+	return writes
+}
+
+// receiverObject returns the named receiver of the method, or nil.
+func (c *checker) receiverObject(fn *ast.FuncDecl) types.Object {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 || len(fn.Recv.List[0].Names) == 0 {
+		return nil
+	}
+	return c.TypesInfo.Defs[fn.Recv.List[0].Names[0]]
+}
+
+// inspectReceiverWrites calls yield for every expression within n which writes the receiver:
+// a (nested) field of it, the whole pointed value or a field whose address is taken.
+func (c *checker) inspectReceiverWrites(n ast.Node, recvObj types.Object, yield func(target ast.Expr)) {
+	if recvObj == nil || n == nil {
+		return
+	}
+	ast.Inspect(n, func(n ast.Node) bool {
+		for _, target := range c.receiverWriteTargets(n, recvObj) {
+			yield(target)
+		}
+		return true
+	})
+}
+
+// receiverWriteTargets returns expressions written by the node itself (not its descendants) which target the receiver.
+func (c *checker) receiverWriteTargets(n ast.Node, recvObj types.Object) []ast.Expr {
+	isRecvTarget := func(expr ast.Expr) bool {
+		switch ast.Unparen(expr).(type) {
+		case *ast.SelectorExpr, *ast.StarExpr:
+			root := rootIdent(expr)
+			return root != nil && c.TypesInfo.Uses[root] == recvObj
+		}
+		return false
+	}
+
+	var targets []ast.Expr
+	switch node := n.(type) {
+	case *ast.AssignStmt:
+		for _, lhs := range node.Lhs {
+			if isRecvTarget(lhs) {
+				targets = append(targets, lhs)
+			}
+		}
+	case *ast.IncDecStmt:
+		if isRecvTarget(node.X) {
+			targets = append(targets, node.X)
+		}
+	case *ast.UnaryExpr:
+		if node.Op == token.AND && isRecvTarget(node.X) {
+			targets = append(targets, node.X)
+		}
+	}
+	return targets
+}
+
+// checkAggregateMemberField reports writes to fields of aggregate members made outside the aggregate.
+// It returns true when the field belongs to an aggregate member, i.e. the write has been fully handled here.
+func (c *checker) checkAggregateMemberField(sel *ast.SelectorExpr) bool {
+	owner, field := c.selectedField(sel)
+	if field == nil {
+		return false
+	}
+	root := c.aggregateRoot(owner)
+	if root == "" {
+		return false
+	}
+
+	if !c.insideAggregate(sel.Pos(), root, owner.Obj().Name()) {
+		c.reportIssuef(sel.Pos(), owner.Obj().Name(), field.Name(), categoryAggregate,
+			"assignment to field %s.%s is forbidden outside methods of its aggregate %s", owner.Obj().Name(), field.Name(), root)
+	}
+	return true
+}
+
+// checkAggregateMemberCall reports calls to mutating methods of aggregate members made outside the aggregate.
+func (c *checker) checkAggregateMemberCall(call *ast.CallExpr) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	selection, ok := c.TypesInfo.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return
+	}
+	named, ok := deref(selection.Recv()).(*types.Named)
+	if !ok {
+		return
+	}
+	root := c.aggregateRoot(named)
+	if root == "" || !c.ImportObjectFact(selection.Obj(), new(mutatorFact)) {
+		return
+	}
+
+	if !c.insideAggregate(call.Pos(), root, named.Obj().Name()) {
+		c.reportIssuef(sel.Sel.Pos(), named.Obj().Name(), sel.Sel.Name, categoryAggregate,
+			"call to mutating method %s.%s is forbidden outside methods of its aggregate %s", named.Obj().Name(), sel.Sel.Name, root)
+	}
+}
+
+// aggregateRoot returns the name of the aggregate root the struct is a member of, or empty string.
+// The config takes precedence over directives, conflicts are reported by checkAggregateDirectives.
+func (c *checker) aggregateRoot(named *types.Named) string {
+	if root := c.configuredRoot(named.Obj().Name()); root != "" {
+		return root
+	}
+	if roots := c.objectDirectives(named.Obj())[aggregateDirective]; len(roots) > 0 {
+		return roots[0]
+	}
+	return ""
+}
+
+// configuredRoot returns the aggregate root listing the member in the config, or empty string.
+// validateAggregates ensures there is one at most.
+func (c *checker) configuredRoot(member string) string {
+	for root, members := range c.Aggregates {
+		if slices.Contains(members, member) {
+			return root
+		}
+	}
+	return ""
+}
+
+// checkAggregateDirectives reports //propro:aggregate directives of structs declared in the analyzed package
+// which name another root than the config or several roots.
+func (c *checker) checkAggregateDirectives() {
+	scope := c.Pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+		roots := c.objectDirectives(tn)[aggregateDirective]
+		if configured := c.configuredRoot(name); configured != "" && len(roots) > 0 {
+			roots = append(roots, configured)
+		}
+		if roots = slices.Compact(slices.Sorted(slices.Values(roots))); len(roots) > 1 {
+			c.report(Issue{
+				Pos:      tn.Pos(),
+				Message:  fmt.Sprintf("struct %s is a member of several aggregates: %s", name, strings.Join(roots, ", ")),
+				Category: categoryConfig,
+				Struct:   name,
+			})
+		}
+	}
+}
+
+// insideAggregate checks whether the position is inside a method of the aggregate root or of the member itself.
+func (c *checker) insideAggregate(pos token.Pos, root, member string) bool {
+	return c.insideStructMethod(pos, root) || c.insideStructMethod(pos, member)
+}
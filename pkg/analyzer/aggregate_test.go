@@ -0,0 +1,32 @@
+package analyzer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAggregates(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg: []string{"Order"},
+		aggregatesArg: map[string]any{
+			"Order": []any{"OrderLine", "Coupon"},
+		},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "aggregate", "aggregateuse")
+}
+
+func TestValidateAggregates(t *testing.T) {
+	cfg := &Config{Aggregates: map[string][]string{"Order": {"Line"}, "Cart": {"Item", "Line"}}}
+	err := validateAggregates(cfg)
+	if !errors.Is(err, ErrInvalidConfigValue) || !strings.Contains(err.Error(), `"Line" is a member of both Cart and Order`) {
+		t.Errorf("validateAggregates() error = %v, want %v", err, ErrInvalidConfigValue)
+	}
+	if err := validateAggregates(&Config{Aggregates: map[string][]string{"Order": {"Line", "Line"}}}); err != nil {
+		t.Errorf("validateAggregates() error = %v", err)
+	}
+}
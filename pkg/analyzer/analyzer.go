@@ -5,10 +5,13 @@ import (
 	"flag"
 	"fmt"
 	"go/ast"
-	"go/parser"
 	"go/token"
 	"go/types"
+	"reflect"
+	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -22,48 +25,189 @@ const (
 	entityListVarName = "EntityList"
 
 	// These must be identical to golangci-lint repo config keys.
-	entityListFileArg = "entityListFile"
-	structsArg        = "structs"
+	entityListFileArg   = "entityListFile"
+	entityListVarsArg   = "entityListVars"
+	structsArg          = "structs"
+	fieldWritersArg     = "fieldWriters"
+	immutableFieldsArg  = "immutableFields"
+	constructorsArg     = "constructors"
+	valueObjectsArg     = "valueObjects"
+	aggregatesArg       = "aggregates"
+	callersArg          = "callers"
+	eventRootsArg       = "eventRoots"
+	eventRecordersArg   = "eventRecorders"
+	invariantMethodsArg = "invariantMethods"
+	hiddenFieldsArg     = "hiddenFields"
+	hiddenReadersArg    = "hiddenFieldReaders"
+	guardedFieldsArg    = "guardedFields"
+	decodeSinksArg      = "decodeSinks"
+	decodeAllowedArg    = "decodeAllowedPackages"
+	ormUpdatesArg       = "ormUpdates"
+	ormModelsArg        = "ormModels"
+	ormAllowedArg       = "ormAllowedPackages"
+	registrationArg     = "registrationFuncs"
+	implementsArg       = "implements"
+	embedsArg           = "embeds"
+	protectedPkgsArg    = "protectedPackages"
+	excludeStructsArg   = "excludeStructs"
+	dtoHeuristicArg     = "dtoHeuristic"
+	testsArg            = "tests"
+	testBuildersArg     = "testBuilders"
+	testPackagesArg     = "testPackages"
+	generatedArg        = "generated"
+	generatorsArg       = "generatedGenerators"
+	ignoreNoReasonArg   = "allowIgnoreWithoutReason"
+
+	// Diagnostic categories.
+	categoryProtected   = "protected"
+	categoryWriters     = "writers"
+	categoryImmutable   = "immutable"
+	categoryValueObject = "value-object"
+	categoryAggregate   = "aggregate"
+	categoryCallers     = "callers"
+	categoryEvents      = "events"
+	categoryInvariants  = "invariants"
+	categoryHidden      = "hidden"
+	categoryGuardedBy   = "guardedby"
+	categoryDecode      = "decode"
+	categoryORM         = "orm"
+	categoryConfig      = "config"
+	categoryTests       = "tests"
+	categorySuppression = "suppression"
 )
 
-var (
-	StructsArgValue string
-	EntityFile      string
-	Structs         []string
+var ErrNotInspectAnalyzer = errors.New("inspect analyzer result is not *inspector.Inspector")
 
-	ProtectedStructsMap map[string]bool
-	protectAllStructs   bool
-	seen                map[string]bool
-	cfg                 map[string]any
+// settings is the configuration of an analyzer created by NewAnalyzer. It is completed by CLI flags and
+// the protected structs are resolved on the first run; afterwards it is immutable and shared by concurrent runs.
+type settings struct {
+	*Config
 
-	ErrNotInspectAnalyzer = errors.New("inspect analyzer result is not *inspector.Inspector")
+	input map[string]any
+	flags *flag.FlagSet
 
-	flagSet flag.FlagSet
-)
+	once       sync.Once
+	err        error
+	protected  map[string]bool // names of protected structs, fully-qualified or simple for unqualified entries
+	protectAll bool
+	warnings   []string // misconfiguration found while resolving the protected structs
+
+	decodeSinks map[string]int // argument indexes of decoding functions, see indexedFuncs
+	ormUpdates  map[string]int // argument indexes of ORM update methods, see indexedFuncs
+	ormModels   map[string]int // argument indexes of ORM model methods, see indexedFuncs
+
+	modules         sync.Map // module root -> *module, see moduleOf
+	protectedByType sync.Map // *types.Named -> bool, see isProtectedStruct
+}
+
+// module is the state derived from a module of analyzed packages. It is resolved on the first run on a package
+// of the module, so each module of a workspace resolves relative patterns and scans registrations on its own.
+type module struct {
+	root       string
+	once       sync.Once
+	err        error
+	reported   atomic.Bool                 // whether the config warnings have been reported in the module
+	registered map[string]bool             // qualified names of types passed to registration functions anywhere in the module
+	types      map[string]bool             // names of types declared in the module, to check struct names against
+	interfaces map[string]*types.Interface // Implements interfaces by qualified names, see loadInterfaces
+	packages   []*regexp.Regexp            // protected packages, see resolveScope
+	testPkgs   []*regexp.Regexp            // test helper packages, see resolveScope
+}
+
+// checker is the state of a single run of the analyzer on a package.
+type checker struct {
+	*analysis.Pass
+	*settings
 
-func init() {
-	flagSet.StringVar(&EntityFile, entityListFileArg, "", "Path to file listing protected structs")
-	flagSet.StringVar(&StructsArgValue, structsArg, "", "Comma-separated list of protected structs")
+	module *module
+
+	registered   map[string]bool // qualified names of structs registered in the package and its dependencies
+	skipped      map[string]bool // names of generated files whose issues are not reported
+	suppressions []*suppression  // //propro:ignore and //propro:ignore-file directives
+	seen         map[string]bool // reported issues
+	issues       []Issue
 }
 
 func NewAnalyzer(inputCfg map[string]any) *analysis.Analyzer {
-	cfg = inputCfg
+	s := &settings{input: inputCfg}
+	a := &analysis.Analyzer{
+		Name:       metaName,
+		Doc:        metaDoc,
+		URL:        metaURL,
+		Requires:   []*analysis.Analyzer{inspect.Analyzer},
+		Run:        s.run,
+		ResultType: reflect.TypeFor[*Result](),
+		FactTypes:  []analysis.Fact{new(directivesFact), new(mutatorFact), new(registeredEntitiesFact)},
+	}
+	s.flags = &a.Flags
+	registerFlags(s.flags)
+
+	return a
+}
 
-	return &analysis.Analyzer{
-		Name:     metaName,
-		Doc:      metaDoc,
-		URL:      metaURL,
-		Requires: []*analysis.Analyzer{inspect.Analyzer},
-		Flags:    flagSet,
-		Run:      run,
-	}
+func registerFlags(fs *flag.FlagSet) {
+	fs.String(entityListFileArg, "", "Comma-separated paths or globs of files listing protected structs")
+	fs.String(entityListVarsArg, "", "Comma-separated list of variables listing protected structs (default EntityList)")
+	fs.String(structsArg, "", "Comma-separated list of protected structs")
+	fs.String(fieldWritersArg, "", "Permitted writers of fields, e.g. Order.Status=Submit,Cancel;Order.Total=AddLine")
+	fs.String(immutableFieldsArg, "", "Comma-separated list of fields immutable after construction, e.g. Order.ID")
+	fs.String(constructorsArg, "", "Comma-separated list of constructors allowed to write immutable fields")
+	fs.String(valueObjectsArg, "", "Comma-separated list of fully immutable value object structs")
+	fs.String(aggregatesArg, "", "Aggregate roots and their members, e.g. Order=OrderLine,ShippingInfo;Cart=CartItem")
+	fs.String(callersArg, "", "Permitted callers of methods, e.g. OrderLine.ApplyDiscount=Order;Order.Restore=persistence")
+	fs.String(eventRootsArg, "", "Comma-separated list of aggregate roots whose mutating methods must record domain events")
+	fs.String(eventRecordersArg, "", "Comma-separated list of event-recording functions, methods or fields (default Record)")
+	fs.String(invariantMethodsArg, "", "Comma-separated list of invariant methods to be called after mutations, e.g. Validate")
+	fs.String(hiddenFieldsArg, "", "Comma-separated list of fields which must not be read outside their methods, e.g. User.PasswordHash")
+	fs.String(hiddenReadersArg, "", "Comma-separated list of packages allowed to read hidden fields")
+	fs.String(guardedFieldsArg, "", "Mutexes guarding fields, e.g. Counter.Count=mu;Counter.Last=mu")
+	fs.String(decodeSinksArg, "", "Comma-separated list of additional decoding functions, e.g. acme.io/codec.Decode:1")
+	fs.String(decodeAllowedArg, "", "Comma-separated list of packages allowed to decode into protected structs")
+	fs.String(ormUpdatesArg, "", "Comma-separated list of additional ORM update methods, e.g. (*acme.io/orm.Query).Set:0")
+	fs.String(ormModelsArg, "", "Comma-separated list of additional ORM methods setting the model, e.g. (*acme.io/orm.DB).Model:0")
+	fs.String(ormAllowedArg, "", "Comma-separated list of packages allowed to update protected structs by ORM column names")
+	fs.String(registrationArg, "", "Comma-separated list of entity registration functions, e.g. (*gorm.io/gorm.DB).AutoMigrate")
+	fs.String(implementsArg, "", "Comma-separated list of interfaces whose implementations are protected, e.g. acme.io/ddd.Entity")
+	fs.String(embedsArg, "", "Comma-separated list of base types whose embedders are protected, e.g. acme.io/ddd.AggregateRoot")
+	fs.String(protectedPkgsArg, "", "Comma-separated list of packages whose structs are protected, e.g. ./internal/domain/...")
+	fs.String(excludeStructsArg, "", "Comma-separated list of patterns of structs which are not protected, e.g. *Fixture")
+	fs.Bool(dtoHeuristicArg, false, "Do not protect structs named like DTOs, e.g. *Request, or with json tags only")
+	fs.String(testsArg, "", "Policy for test code: enforce (default), ignore or builders-only")
+	fs.String(testBuildersArg, "", "Comma-separated list of patterns of test builders (default *Builder.*,given*)")
+	fs.String(testPackagesArg, "", "Comma-separated list of test helper packages, e.g. ./internal/testutil/...")
+	fs.String(generatedArg, "", "Policy for generated files: skip (default) or enforce")
+	fs.String(generatorsArg, "", "Comma-separated list of generators whose files are skipped (default all), e.g. stringer")
+	fs.Bool(ignoreNoReasonArg, false, "Allow //propro:ignore and //propro:ignore-file directives without a reason")
 }
 
-func run(pass *analysis.Pass) (any, error) {
-	setUpFromInput()
+func (s *settings) run(pass *analysis.Pass) (any, error) {
+	s.once.Do(func() { s.err = s.resolve() })
+	if s.err != nil {
+		return nil, s.err
+	}
 
-	seen = make(map[string]bool)
+	m, err := s.moduleOf(pass)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &checker{Pass: pass, settings: s, module: m, seen: map[string]bool{}}
+	c.findSkippedGeneratedFiles()
+	c.findSuppressions()
+	c.discoverRegisteredEntities()
+	c.reportConfigWarnings()
+	c.checkEmptyProtectedStructs()
+
+	c.exportDirectiveFacts()
+	c.checkAggregateDirectives()
+	c.exportMutatorFacts()
+	c.checkValueObjectReceivers()
+	c.checkEventRecording()
+	c.checkInvariantHooks()
+	c.checkGuardedWrites()
+	c.checkTestBuilderImports()
 	aliasMap := map[types.Object]*ast.SelectorExpr{}
+	writeTargets := map[ast.Expr]bool{}
 
 	insp, ok := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	if !ok {
@@ -74,142 +218,146 @@ func run(pass *analysis.Pass) (any, error) {
 		(*ast.AssignStmt)(nil),
 		(*ast.IncDecStmt)(nil),
 		(*ast.CallExpr)(nil),
+		(*ast.SelectorExpr)(nil),
+		(*ast.Ident)(nil),
 	}
 
 	insp.Preorder(inNodes, func(n ast.Node) {
 		switch node := n.(type) {
 		case *ast.AssignStmt:
-			handleAssignStmt(pass, node, aliasMap)
+			for _, lhs := range node.Lhs {
+				writeTargets[ast.Unparen(lhs)] = true
+			}
+			c.handleAssignStmt(node, aliasMap)
 		case *ast.IncDecStmt:
-			handleIncDecStmt(pass, node, aliasMap)
+			writeTargets[ast.Unparen(node.X)] = true
+			c.handleIncDecStmt(node, aliasMap)
 		case *ast.CallExpr:
-			handleCallExpr(pass, node, aliasMap)
+			c.handleCallExpr(node, aliasMap)
+			c.checkAggregateMemberCall(node)
+			c.checkHiddenFieldLogging(node)
+			c.checkDecodeSink(node)
+			c.checkORMUpdate(node)
+		case *ast.SelectorExpr:
+			c.checkHiddenFieldRead(node, writeTargets)
+		case *ast.Ident:
+			c.checkRestrictedCall(node)
 		}
 	})
 
-	return nil, nil
-}
+	c.reportUnusedSuppressions()
 
-// setUpFromInput initializes EntityFile and Structs from cfg or CLI, then builds ProtectedStructsMap.
-func setUpFromInput() {
-	tryInitFromCfg()
-	if EntityFile == "" && len(Structs) == 0 {
-		tryInitFromCLI()
-	}
-	buildProtectedStructMap()
+	return &Result{Protected: c.protectedStructs(), Issues: c.issues}, nil
 }
 
-func tryInitFromCfg() {
-	if v, ok := cfg[entityListFileArg].(string); ok && v != "" {
-		EntityFile = v
+// resolve decodes the config, completes it by CLI flags and resolves the protected structs.
+// Entities loaded from entity list files are stored by their fully-qualified names, configured structs as given.
+func (s *settings) resolve() error {
+	cfg, err := DecodeConfig(s.input)
+	if err != nil {
+		return err
 	}
-	if v, ok := cfg[structsArg].([]string); ok && len(v) > 0 {
-		Structs = append(Structs, v...)
+	if err := mergeFlags(cfg, s.flags); err != nil {
+		return err
 	}
-}
-
-// tryInitFromCLI initializes EntityFile and Structs from CLI flags.
-func tryInitFromCLI() {
-	entityListNameFlag := flagSet.Lookup(entityListFileArg)
-	if entityListNameFlag != nil && entityListNameFlag.Value.String() != "" {
-		EntityFile = strings.TrimSpace(entityListNameFlag.Value.String())
+	if err := validateTestsPolicy(cfg); err != nil {
+		return err
 	}
-
-	structsFlag := flagSet.Lookup(structsArg)
-	if structsFlag != nil && structsFlag.Value.String() != "" {
-		parts := strings.Split(structsFlag.Value.String(), ",")
-		for _, part := range parts {
-			part = strings.TrimSpace(part)
-			if part != "" {
-				Structs = append(Structs, part)
-			}
-		}
+	if err := validateGeneratedPolicy(cfg); err != nil {
+		return err
 	}
-}
-
-// buildProtectedStructMap populates ProtectedStructsMap and sets protectAllStructs when empty.
-func buildProtectedStructMap() {
-	if len(ProtectedStructsMap) > 0 || protectAllStructs {
-		// Concurrency expected: already built
-		return
+	if err := validateAggregates(cfg); err != nil {
+		return err
 	}
+	if s.decodeSinks, err = indexedFuncs(decodeSinksArg, builtinDecodeSinks, cfg.DecodeSinks); err != nil {
+		return err
+	}
+	if s.ormUpdates, err = indexedFuncs(ormUpdatesArg, builtinORMUpdates, cfg.ORMUpdates); err != nil {
+		return err
+	}
+	if s.ormModels, err = indexedFuncs(ormModelsArg, builtinORMModels, cfg.ORMModels); err != nil {
+		return err
+	}
+	s.Config = cfg
+	s.protected = map[string]bool{}
 
-	ProtectedStructsMap = make(map[string]bool)
-
-	if EntityFile != "" {
-		for k := range loadEntityList(EntityFile) {
-			ProtectedStructsMap[k] = true
+	for _, file := range cfg.EntityListFile {
+		entities, err := loadEntityList([]string{file}, cfg.EntityListVars)
+		if err != nil {
+			return err
+		}
+		if len(entities) == 0 {
+			s.warnings = append(s.warnings, fmt.Sprintf("entity list file %s yields no entries", file))
+		}
+		for k := range entities {
+			s.protected[k] = true
 		}
 	}
 
-	for _, s := range Structs {
-		s = strings.TrimSpace(s)
-		if s != "" {
-			ProtectedStructsMap[s] = true
+	for _, name := range cfg.Structs {
+		if name = strings.TrimSpace(name); name != "" {
+			s.protected[name] = true
 		}
 	}
 
-	if len(ProtectedStructsMap) == 0 {
-		protectAllStructs = true
-	}
+	s.protectAll = len(s.protected) == 0 && len(cfg.Implements) == 0 && len(cfg.Embeds) == 0 &&
+		len(cfg.RegistrationFuncs) == 0 && len(cfg.ProtectedPackages) == 0
+	return nil
 }
 
-// loadEntityList reads a file containing a var EntityList = []Type{...}.
-func loadEntityList(filePath string) map[string]bool {
-	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, filePath, nil, parser.AllErrors)
-	if err != nil {
-		return map[string]bool{}
-	}
-
-	out := map[string]bool{}
+// moduleOf returns the state of the module containing the analyzed package, resolving it on the first call.
+func (s *settings) moduleOf(pass *analysis.Pass) (*module, error) {
+	root := passModuleRoot(pass)
+	v, _ := s.modules.LoadOrStore(root, &module{root: root})
+	m, _ := v.(*module)
+	m.once.Do(func() { m.err = s.resolveModule(m, root) })
+	return m, m.err
+}
 
-	for _, decl := range f.Decls {
-		gd, ok := decl.(*ast.GenDecl)
-		if !ok || gd.Tok != token.VAR {
-			continue
-		}
-		for _, spec := range gd.Specs {
-			vs, ok := spec.(*ast.ValueSpec)
-			if !ok || len(vs.Names) == 0 || vs.Names[0].Name != entityListVarName {
-				continue
-			}
-			for _, v := range vs.Values {
-				cl, ok := v.(*ast.CompositeLit)
-				if !ok {
-					continue
-				}
-				for _, elt := range cl.Elts {
-					if name := extractTypeName(elt); name != "" {
-						out[name] = true
-					}
-				}
+// resolveModule scans the module for registrations and declared types and resolves the package patterns against
+// the module path. Modules of dependencies, i.e. of the standard library and the module cache, are not scanned.
+func (s *settings) resolveModule(m *module, root string) error {
+	m.registered = map[string]bool{}
+	if root != "" && !isDependencyModule(root) {
+		if len(s.RegistrationFuncs) > 0 {
+			for _, name := range scanModuleRegistrations(root, s.RegistrationFuncs) {
+				m.registered[name] = true
 			}
 		}
+		if len(s.Structs) > 0 {
+			m.types = moduleTypeNames(root)
+		}
+		if len(s.Implements) > 0 {
+			m.interfaces = loadInterfaces(root, s.Implements)
+		}
 	}
-	return out
+	return m.resolveScope(s.Config, root)
 }
 
 // handleAssignStmt processes assignments and checks mutations.
-func handleAssignStmt(pass *analysis.Pass, node *ast.AssignStmt, aliasMap map[types.Object]*ast.SelectorExpr) {
-	trackAlias(pass, node, aliasMap)
+func (c *checker) handleAssignStmt(node *ast.AssignStmt, aliasMap map[types.Object]*ast.SelectorExpr) {
+	c.trackAlias(node, aliasMap)
 	for _, lhs := range node.Lhs {
-		if sel := resolveMutationTarget(pass, lhs, aliasMap); sel != nil {
-			handleSelectorMutation(pass, sel)
+		if node.Tok == token.ASSIGN {
+			c.checkWholeStructOverwrite(lhs)
+			c.checkValueObjectOverwrite(lhs)
+		}
+		if sel := c.resolveMutationTarget(lhs, aliasMap); sel != nil {
+			c.handleSelectorMutation(sel)
 		}
 	}
 }
 
 // handleIncDecStmt handles ++/-- operations.
-func handleIncDecStmt(pass *analysis.Pass, node *ast.IncDecStmt, aliasMap map[types.Object]*ast.SelectorExpr) {
-	if sel := resolveMutationTarget(pass, node.X, aliasMap); sel != nil {
-		handleSelectorMutation(pass, sel)
+func (c *checker) handleIncDecStmt(node *ast.IncDecStmt, aliasMap map[types.Object]*ast.SelectorExpr) {
+	if sel := c.resolveMutationTarget(node.X, aliasMap); sel != nil {
+		c.handleSelectorMutation(sel)
 	}
 }
 
 // handleCallExpr tracks argument aliases in function calls.
-func handleCallExpr(pass *analysis.Pass, node *ast.CallExpr, aliasMap map[types.Object]*ast.SelectorExpr) {
-	fnType := pass.TypesInfo.TypeOf(node.Fun)
+func (c *checker) handleCallExpr(node *ast.CallExpr, aliasMap map[types.Object]*ast.SelectorExpr) {
+	fnType := c.TypesInfo.TypeOf(node.Fun)
 	sig, ok := fnType.(*types.Signature)
 	if !ok {
 		return
@@ -223,7 +371,7 @@ func handleCallExpr(pass *analysis.Pass, node *ast.CallExpr, aliasMap map[types.
 }
 
 // trackAlias captures simple aliasing like: x := &e.Field.
-func trackAlias(pass *analysis.Pass, node *ast.AssignStmt, aliasMap map[types.Object]*ast.SelectorExpr) {
+func (c *checker) trackAlias(node *ast.AssignStmt, aliasMap map[types.Object]*ast.SelectorExpr) {
 	if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
 		return
 	}
@@ -239,13 +387,13 @@ func trackAlias(pass *analysis.Pass, node *ast.AssignStmt, aliasMap map[types.Ob
 	if sel == nil {
 		return
 	}
-	if obj := pass.TypesInfo.ObjectOf(lhsIdent); obj != nil {
+	if obj := c.TypesInfo.ObjectOf(lhsIdent); obj != nil {
 		aliasMap[obj] = sel
 	}
 }
 
 // resolveMutationTarget centralizes all ways an expression can represent a mutation target.
-func resolveMutationTarget(pass *analysis.Pass, expr ast.Expr, aliasMap map[types.Object]*ast.SelectorExpr) *ast.SelectorExpr {
+func (c *checker) resolveMutationTarget(expr ast.Expr, aliasMap map[types.Object]*ast.SelectorExpr) *ast.SelectorExpr {
 	// Direct selector: e.Field or parentheses/star/unary wrapping
 	if sel := unwrapSelectorExpr(expr); sel != nil {
 		return sel
@@ -255,12 +403,12 @@ func resolveMutationTarget(pass *analysis.Pass, expr ast.Expr, aliasMap map[type
 	switch e := expr.(type) {
 	case *ast.StarExpr:
 		if id, ok := e.X.(*ast.Ident); ok {
-			if obj := pass.TypesInfo.ObjectOf(id); obj != nil {
+			if obj := c.TypesInfo.ObjectOf(id); obj != nil {
 				return aliasMap[obj]
 			}
 		}
 	case *ast.Ident:
-		if obj := pass.TypesInfo.ObjectOf(e); obj != nil {
+		if obj := c.TypesInfo.ObjectOf(e); obj != nil {
 			return aliasMap[obj]
 		}
 	}
@@ -268,17 +416,22 @@ func resolveMutationTarget(pass *analysis.Pass, expr ast.Expr, aliasMap map[type
 }
 
 // handleSelectorMutation validates selector and reports if it's a forbidden mutation.
-func handleSelectorMutation(pass *analysis.Pass, sel *ast.SelectorExpr) {
-	structName, fieldName, protectionViolated := guardProtectedFieldMutation(pass, sel)
+func (c *checker) handleSelectorMutation(sel *ast.SelectorExpr) {
+	if c.checkValueObjectField(sel) || c.checkImmutableField(sel) || c.checkFieldWriters(sel) ||
+		c.checkAggregateMemberField(sel) || c.checkGuardedFieldOutsideMethods(sel) {
+		return
+	}
+
+	structName, fieldName, protectionViolated := c.guardProtectedFieldMutation(sel)
 	if !protectionViolated {
 		return
 	}
-	reportIssue(pass, sel.Pos(), structName, fieldName)
+	c.reportIssue(sel.Pos(), structName, fieldName)
 }
 
 // guardProtectedFieldMutation does the heavy checks: exported, protected struct, embedded, method.
-func guardProtectedFieldMutation(pass *analysis.Pass, sel *ast.SelectorExpr) (structName, fieldName string, protectionViolated bool) {
-	if pass.TypesInfo == nil || sel == nil {
+func (c *checker) guardProtectedFieldMutation(sel *ast.SelectorExpr) (structName, fieldName string, protectionViolated bool) {
+	if c.TypesInfo == nil || sel == nil {
 		return "", "", false
 	}
 
@@ -287,7 +440,7 @@ func guardProtectedFieldMutation(pass *analysis.Pass, sel *ast.SelectorExpr) (st
 		return "", "", false
 	}
 
-	typ := pass.TypesInfo.TypeOf(sel.X)
+	typ := c.TypesInfo.TypeOf(sel.X)
 	if typ == nil {
 		return "", "", false
 	}
@@ -299,15 +452,15 @@ func guardProtectedFieldMutation(pass *analysis.Pass, sel *ast.SelectorExpr) (st
 	}
 	structName = named.Obj().Name()
 
-	if !protectAllStructs && !ProtectedStructsMap[structName] {
+	if !c.isProtectedStruct(named) {
 		return "", "", false
 	}
 
-	if isEmbeddedField(pass, sel, fieldName) {
+	if c.isEmbeddedField(sel, fieldName) {
 		return "", "", false
 	}
 
-	if insideStructMethod(pass, sel.Pos(), structName) {
+	if c.insideStructMethod(sel.Pos(), structName) {
 		return "", "", false
 	}
 
@@ -326,13 +479,13 @@ func deref(t types.Type) types.Type {
 }
 
 // insideStructMethod checks if the position is inside a method of the given struct.
-func insideStructMethod(pass *analysis.Pass, pos token.Pos, structName string) bool {
-	fn := findEnclosingFunc(pass, pos)
+func (c *checker) insideStructMethod(pos token.Pos, structName string) bool {
+	fn := c.findEnclosingFunc(pos)
 	if fn == nil || fn.Recv == nil {
 		return false
 	}
 	for _, recv := range fn.Recv.List {
-		if isSameStructType(pass.TypesInfo.TypeOf(recv.Type), structName) {
+		if isSameStructType(c.TypesInfo.TypeOf(recv.Type), structName) {
 			return true
 		}
 	}
@@ -340,8 +493,8 @@ func insideStructMethod(pass *analysis.Pass, pos token.Pos, structName string) b
 }
 
 // findEnclosingFunc finds the function declaration enclosing the given position.
-func findEnclosingFunc(pass *analysis.Pass, pos token.Pos) *ast.FuncDecl {
-	for _, file := range pass.Files {
+func (c *checker) findEnclosingFunc(pos token.Pos) *ast.FuncDecl {
+	for _, file := range c.Files {
 		var found *ast.FuncDecl
 		ast.Inspect(file, func(n ast.Node) bool {
 			if f, ok := n.(*ast.FuncDecl); ok {
@@ -372,8 +525,8 @@ func namedTypeName(t types.Type) string {
 }
 
 // isEmbeddedField checks if the field is embedded in the struct.
-func isEmbeddedField(pass *analysis.Pass, sel *ast.SelectorExpr, fieldName string) bool {
-	t := deref(pass.TypesInfo.TypeOf(sel.X))
+func (c *checker) isEmbeddedField(sel *ast.SelectorExpr, fieldName string) bool {
+	t := deref(c.TypesInfo.TypeOf(sel.X))
 	n, ok := t.(*types.Named)
 	if !ok {
 		return false
@@ -394,14 +547,38 @@ func isEmbeddedField(pass *analysis.Pass, sel *ast.SelectorExpr, fieldName strin
 }
 
 // reportIssue reports the forbidden mutation if not already reported.
-func reportIssue(pass *analysis.Pass, pos token.Pos, structName, fieldName string) {
+func (c *checker) reportIssue(pos token.Pos, structName, fieldName string) {
+	c.reportIssuef(pos, structName, fieldName, categoryProtected,
+		"assignment to exported field %s.%s is forbidden outside its methods", structName, fieldName)
+}
+
+// exempt checks whether issues at the position are not reported due to the tests or generated code policies.
+func (c *checker) exempt(pos token.Pos) bool {
+	return c.skipped[c.Fset.File(pos).Name()] || c.exemptByTestsPolicy(pos)
+}
+
+// reportIssuef reports an issue of the given category with a custom message if not already reported for the field at pos.
+// The issue is also recorded in the Result with its struct, field and enclosing function.
+func (c *checker) reportIssuef(pos token.Pos, structName, fieldName, category, format string, args ...any) {
 	key := fmt.Sprintf("%s.%s.%d", structName, fieldName, pos)
-	if seen[key] {
+	if c.seen[key] || c.exempt(pos) {
 		return
 	}
-	seen[key] = true
+	c.seen[key] = true
+	c.report(Issue{Pos: pos, Message: fmt.Sprintf(format, args...), Category: category, Struct: structName, Field: fieldName})
+}
 
-	pass.Reportf(pos, "assignment to exported field %s.%s is forbidden outside its methods", structName, fieldName)
+// report records and reports the issue unless it is suppressed by a //propro:ignore or //propro:ignore-file directive.
+// Unlike reportIssuef, it does not apply the tests and generated code policies, e.g. to config warnings.
+func (c *checker) report(issue Issue) {
+	if c.suppressed(issue.Pos) {
+		return
+	}
+	if fn := c.findEnclosingFunc(issue.Pos); fn != nil {
+		issue.Func = funcDeclName(fn)
+	}
+	c.issues = append(c.issues, issue)
+	c.Report(analysis.Diagnostic{Pos: issue.Pos, Category: issue.Category, Message: issue.Message})
 }
 
 // extractTypeName extracts the type name from an expression.
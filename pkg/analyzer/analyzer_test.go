@@ -2,26 +2,34 @@ package analyzer
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
-func setUp() string {
-	ProtectedStructsMap = make(map[string]bool)
-	EntityFile = ""
-	Structs = []string{}
-
+func testdataDir() string {
 	path, _ := os.Getwd()
 	testdata := filepath.Join(filepath.Dir(filepath.Dir(path)), "testdata")
 
 	return testdata
 }
 
+// gopathEnv makes entity list files in testdata resolve their imports in GOPATH mode, like analysistest packages.
+func gopathEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("GO111MODULE", "off")
+	t.Setenv("GOPATH", testdataDir())
+	t.Setenv("GOFLAGS", "")
+}
+
 func TestWithEntityFileParameter(t *testing.T) {
-	testdata := setUp()
+	testdata := testdataDir()
+	gopathEnv(t)
 
 	cfg := map[string]any{
 		entityListFileArg: filepath.Join(testdata, "src/config/entities.go"),
@@ -31,7 +39,7 @@ func TestWithEntityFileParameter(t *testing.T) {
 }
 
 func TestWithStructsParameter(t *testing.T) {
-	testdata := setUp()
+	testdata := testdataDir()
 	cfg := map[string]any{
 		// contains UnProtectedEntity to test that only specified structs are protected
 		structsArg: []string{"Entity", "SubEntity"},
@@ -41,7 +49,8 @@ func TestWithStructsParameter(t *testing.T) {
 }
 
 func TestWithEntityFileAndStructsWithOverlap(t *testing.T) {
-	testdata := setUp()
+	testdata := testdataDir()
+	gopathEnv(t)
 	cfg := map[string]any{
 		// contains UnProtectedEntity to test that only specified structs are protected
 		entityListFileArg: filepath.Join(testdata, "src/config/entities.go"),
@@ -52,7 +61,8 @@ func TestWithEntityFileAndStructsWithOverlap(t *testing.T) {
 }
 
 func TestWithEntityFileAndStructsComposed(t *testing.T) {
-	testdata := setUp()
+	testdata := testdataDir()
+	gopathEnv(t)
 	cfg := map[string]any{
 		// contains UnProtectedEntity to test that only specified structs are protected
 		entityListFileArg: filepath.Join(testdata, "src/config2/entities.go"), // Entity
@@ -64,8 +74,27 @@ func TestWithEntityFileAndStructsComposed(t *testing.T) {
 	analysistest.Run(t, testdata, NewAnalyzer(cfg), "protectselected")
 }
 
+func TestQualifiedEntityList(t *testing.T) {
+	testdata := testdataDir()
+	gopathEnv(t)
+	cfg := map[string]any{
+		entityListFileArg: filepath.Join(testdata, "src/qualified/entities/entities.go"),
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "qualified/users", "qualified/admin")
+}
+
+func TestQualifiedStructs(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg: []string{"qualified/users.User"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "qualified/users", "qualified/admin")
+}
+
 func TestWithEntityFileWhichDoesNotCompile(t *testing.T) {
-	testdata := setUp()
+	testdata := testdataDir()
 	cfg := map[string]any{
 		entityListFileArg: filepath.Join(testdata, "src/config3/entities.go.txt"),
 		structsArg: []string{
@@ -75,33 +104,81 @@ func TestWithEntityFileWhichDoesNotCompile(t *testing.T) {
 		},
 	}
 
-	analysistest.Run(t, testdata, NewAnalyzer(cfg), "protectall")
+	rec := &errorRecorder{}
+	analysistest.Run(rec, testdata, NewAnalyzer(cfg), "protectall")
+	if !slices.ContainsFunc(rec.errors, func(msg string) bool { return strings.Contains(msg, ErrEntityListLoad.Error()) }) {
+		t.Errorf("expected entity list load error, got: %v", rec.errors)
+	}
+}
+
+// errorRecorder collects errors reported by analysistest instead of failing the test.
+type errorRecorder struct {
+	errors []string
+}
+
+func (r *errorRecorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
 }
 
 func TestWithNoParameters_allStructsAreProtected(t *testing.T) {
-	testdata := setUp()
+	testdata := testdataDir()
 
 	// UnProtectedEntity WILL also be protected in this test
 	analysistest.Run(t, testdata, NewAnalyzer(map[string]any{}), "protectall")
 }
 
-func TestTryInitFromCLI(t *testing.T) {
-	_ = setUp()
+func TestConfigFromCLI(t *testing.T) {
+	testdata := testdataDir()
+	a := NewAnalyzer(map[string]any{})
 
-	fs := flag.NewFlagSet("test", flag.ContinueOnError)
-	fs.String(entityListFileArg, "", "path to file containing list of entities")
-	fs.String(structsArg, "", "comma separated list of structs to protect")
+	_ = a.Flags.Set(structsArg, "   Entity   ,   SubEntity")
 
-	_ = fs.Set(structsArg, "   Entity   ,   Entity2")
-	_ = fs.Set(entityListFileArg, "      /path/to/file.go    ")
-	flagSet = *fs
+	analysistest.Run(t, testdata, a, "protectselected")
+}
 
-	tryInitFromCLI()
+func TestConfigFromCLIOverridesConfig(t *testing.T) {
+	s := &settings{input: map[string]any{structsArg: []any{"Entity"}, testsArg: testsIgnore}, flags: &flag.FlagSet{}}
+	registerFlags(s.flags)
+	_ = s.flags.Set(structsArg, "Entity2")
+	_ = s.flags.Set(entityListFileArg, "      /path/to/file.go    ")
 
-	if len(Structs) != 2 || Structs[0] != "Entity" || Structs[1] != "Entity2" {
-		t.Errorf("tryInitFromCLI did not set Structs correctly, got: %v", Structs)
+	cfg, err := DecodeConfig(s.input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mergeFlags(cfg, s.flags); err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Structs) != 1 || cfg.Structs[0] != "Entity2" {
+		t.Errorf("mergeFlags did not override Structs, got: %v", cfg.Structs)
 	}
-	if EntityFile != "/path/to/file.go" {
-		t.Errorf("tryInitFromCLI did not set EntityFile correctly, got: %s", EntityFile)
+	if cfg.Tests != testsIgnore {
+		t.Errorf("mergeFlags overrode Tests by the flag default, got: %q", cfg.Tests)
+	}
+	if len(cfg.EntityListFile) != 1 || cfg.EntityListFile[0] != "/path/to/file.go" {
+		t.Errorf("mergeFlags did not set EntityListFile correctly, got: %v", cfg.EntityListFile)
+	}
+}
+
+func TestAnalyzersDoNotShareConfig(t *testing.T) {
+	testdata := testdataDir()
+	selected := NewAnalyzer(map[string]any{structsArg: []string{"Entity", "SubEntity"}})
+	all := NewAnalyzer(map[string]any{})
+
+	t.Run("selected", func(t *testing.T) {
+		t.Parallel()
+		analysistest.Run(t, testdata, selected, "protectselected")
+	})
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+		analysistest.Run(t, testdata, all, "protectall")
+	})
+}
+
+func TestRulesReturnsCopy(t *testing.T) {
+	got := Rules()
+	got[0].ID = "changed"
+	if Rules()[0].ID != categoryProtected {
+		t.Errorf("Rules() shares its slice with callers")
 	}
 }
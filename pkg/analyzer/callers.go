@@ -0,0 +1,74 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+const (
+	// internalDirective restricts calls of a method or function to its declaring package: //propro:internal.
+	internalDirective = "internal"
+	// callersDirective restricts calls of a method or function to methods of the given types
+	// or to the given packages: //propro:callers=Order.
+	callersDirective = "callers"
+)
+
+// checkRestrictedCall reports references to internal or caller-restricted methods and functions
+// made outside their permitted callers. Identifiers of qualified and unqualified references are checked alike,
+// so method values and calls within the declaring package are covered as well as calls.
+func (c *checker) checkRestrictedCall(id *ast.Ident) {
+	fn, ok := c.TypesInfo.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return
+	}
+	typeName, name := funcOwnerName(fn), qualifiedFuncName(fn)
+
+	callers, restricted := c.Callers[name]
+	directives := c.objectDirectives(fn)
+	if !restricted {
+		callers, restricted = directives[callersDirective]
+	}
+	if restricted {
+		if !c.permittedCaller(id.Pos(), typeName, callers) {
+			c.reportIssuef(id.Pos(), typeName, fn.Name(), categoryCallers,
+				"call to %s is forbidden outside its permitted callers: %s", name, strings.Join(callers, ", "))
+		}
+		return
+	}
+
+	if _, internal := directives[internalDirective]; internal && c.Pkg != fn.Pkg() {
+		c.reportIssuef(id.Pos(), typeName, fn.Name(), categoryCallers,
+			"call to internal %s is forbidden outside package %s", name, fn.Pkg().Name())
+	}
+}
+
+// permittedCaller checks whether the reference is inside a method of the declaring type or of one of
+// the caller types, or in one of the caller packages (matched by import path or name).
+func (c *checker) permittedCaller(pos token.Pos, typeName string, callers []string) bool {
+	if typeName != "" && c.insideStructMethod(pos, typeName) {
+		return true
+	}
+	return slices.ContainsFunc(callers, func(caller string) bool {
+		return caller == c.Pkg.Path() || caller == c.Pkg.Name() || c.insideStructMethod(pos, caller)
+	})
+}
+
+// funcOwnerName returns the receiver type name of a method, or empty string for functions.
+func funcOwnerName(fn *types.Func) string {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return ""
+	}
+	return namedTypeName(deref(sig.Recv().Type()))
+}
+
+// qualifiedFuncName returns "Type.Method" for methods and "Func" for functions.
+func qualifiedFuncName(fn *types.Func) string {
+	if owner := funcOwnerName(fn); owner != "" {
+		return owner + "." + fn.Name()
+	}
+	return fn.Name()
+}
@@ -0,0 +1,19 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRestrictedCallers(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg: []string{"Order", "OrderLine"},
+		callersArg: map[string][]string{
+			"Order.Close": {"Service"},
+		},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "callers", "callersuse")
+}
@@ -0,0 +1,354 @@
+package analyzer
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrUnknownConfigKey   = errors.New("unknown config key")
+	ErrInvalidConfigValue = errors.New("invalid config value")
+)
+
+// Config is the configuration of the analyzer as passed by golangci-lint settings.
+// Keys are camelCase, e.g. entityListFile; kebab-case keys like entity-list-file are accepted as well.
+// Keys are case-insensitive, as golangci-lint lowercases keys of plugin settings.
+type Config struct {
+	EntityListFile           []string            `config:"entityListFile"`
+	EntityListVars           []string            `config:"entityListVars"`
+	Structs                  []string            `config:"structs"`
+	FieldWriters             map[string][]string `config:"fieldWriters"`
+	ImmutableFields          []string            `config:"immutableFields"`
+	Constructors             []string            `config:"constructors"`
+	ValueObjects             []string            `config:"valueObjects"`
+	Aggregates               map[string][]string `config:"aggregates"`
+	Callers                  map[string][]string `config:"callers"`
+	EventRoots               []string            `config:"eventRoots"`
+	EventRecorders           []string            `config:"eventRecorders"`
+	InvariantMethods         []string            `config:"invariantMethods"`
+	HiddenFields             []string            `config:"hiddenFields"`
+	HiddenFieldReaders       []string            `config:"hiddenFieldReaders"`
+	GuardedFields            map[string][]string `config:"guardedFields"`
+	DecodeSinks              []string            `config:"decodeSinks"`
+	DecodeAllowedPackages    []string            `config:"decodeAllowedPackages"`
+	ORMUpdates               []string            `config:"ormUpdates"`
+	ORMModels                []string            `config:"ormModels"`
+	ORMAllowedPackages       []string            `config:"ormAllowedPackages"`
+	RegistrationFuncs        []string            `config:"registrationFuncs"`
+	Implements               []string            `config:"implements"`
+	Embeds                   []string            `config:"embeds"`
+	ProtectedPackages        []string            `config:"protectedPackages"`
+	ExcludeStructs           []string            `config:"excludeStructs"`
+	DTOHeuristic             bool                `config:"dtoHeuristic"`
+	Tests                    string              `config:"tests"`
+	TestBuilders             []string            `config:"testBuilders"`
+	TestPackages             []string            `config:"testPackages"`
+	Generated                string              `config:"generated"`
+	GeneratedGenerators      []string            `config:"generatedGenerators"`
+	AllowIgnoreWithoutReason bool                `config:"allowIgnoreWithoutReason"`
+}
+
+// DecodeConfig strictly decodes the settings map. Unknown keys and values of wrong types are reported as errors.
+// Lists may be given as lists of strings or comma-separated strings, maps of lists as maps with list
+// or string values, or "key=a,b;key2=c" strings.
+func DecodeConfig(settings map[string]any) (*Config, error) {
+	c := &Config{}
+	fields := map[string]int{}
+	for key, i := range configFields() {
+		fields[configKey(key)] = i
+	}
+	v := reflect.ValueOf(c).Elem()
+
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(settings)) {
+		i, ok := fields[configKey(key)]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrUnknownConfigKey, key))
+			continue
+		}
+		if err := decodeConfigValue(v.Field(i), settings[key]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return c, nil
+}
+
+func decodeConfigValue(field reflect.Value, value any) error {
+	if value == nil {
+		return nil
+	}
+	var decoded any
+	var err error
+	switch field.Interface().(type) {
+	case []string:
+		decoded, err = strictStringSlice(value)
+	case map[string][]string:
+		decoded, err = strictStringListMap(value)
+	case bool:
+		decoded, err = strictBool(value)
+	case string:
+		decoded, err = strictString(value)
+	}
+	if err != nil {
+		return err
+	}
+	field.Set(reflect.ValueOf(decoded))
+	return nil
+}
+
+// strictStringSlice converts a list of strings or a comma-separated string.
+func strictStringSlice(value any) ([]string, error) {
+	switch val := value.(type) {
+	case string:
+		return splitList(val), nil
+	case []string:
+		return val, nil
+	case []any:
+		out := make([]string, 0, len(val))
+		for _, item := range val {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: expected list of strings, got item %v of type %T", ErrInvalidConfigValue, item, item)
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("%w: expected list of strings, got %T", ErrInvalidConfigValue, value)
+}
+
+// strictString converts a string.
+func strictString(value any) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: expected string, got %T", ErrInvalidConfigValue, value)
+	}
+	return strings.TrimSpace(s), nil
+}
+
+// strictBool converts a bool or a string like "true".
+func strictBool(value any) (bool, error) {
+	switch val := value.(type) {
+	case bool:
+		return val, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(val))
+		if err != nil {
+			return false, fmt.Errorf("%w: expected bool, got %q", ErrInvalidConfigValue, val)
+		}
+		return b, nil
+	}
+	return false, fmt.Errorf("%w: expected bool, got %T", ErrInvalidConfigValue, value)
+}
+
+// strictStringListMap converts a map with list or string values, or a "key=a,b;key2=c" string.
+func strictStringListMap(value any) (map[string][]string, error) {
+	out := map[string][]string{}
+	switch val := value.(type) {
+	case string:
+		return parseStringListMap(val)
+	case map[string][]string:
+		return val, nil
+	case map[string]any:
+		for k, items := range val {
+			list, err := strictStringSlice(items)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			out[k] = list
+		}
+		return out, nil
+	case map[any]any:
+		for k, items := range val {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: expected string key, got %v of type %T", ErrInvalidConfigValue, k, k)
+			}
+			list, err := strictStringSlice(items)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			out[key] = list
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("%w: expected map of lists, got %T", ErrInvalidConfigValue, value)
+}
+
+// parseStringListMap parses a "key=a,b;key2=c" string as used by CLI flags. Empty entries are skipped,
+// entries without a key or "=" are errors.
+func parseStringListMap(s string) (map[string][]string, error) {
+	out := map[string][]string{}
+	for _, entry := range strings.Split(s, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		k, items, ok := strings.Cut(entry, "=")
+		if k = strings.TrimSpace(k); !ok || k == "" {
+			return nil, fmt.Errorf("%w: expected key=a,b, got %q", ErrInvalidConfigValue, strings.TrimSpace(entry))
+		}
+		out[k] = splitList(items)
+	}
+	return out, nil
+}
+
+// configFields maps config keys to indexes of Config fields.
+func configFields() map[string]int {
+	out := map[string]int{}
+	t := reflect.TypeFor[Config]()
+	for i := range t.NumField() {
+		out[t.Field(i).Tag.Get("config")] = i
+	}
+	return out
+}
+
+// camelCase converts kebab-case keys like entity-list-file to camelCase.
+func camelCase(key string) string {
+	parts := strings.Split(key, "-")
+	for i := 1; i < len(parts); i++ {
+		if r := []rune(parts[i]); len(r) > 0 {
+			r[0] = unicode.ToUpper(r[0])
+			parts[i] = string(r)
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// mergeFlags overrides the config by CLI flags named like the config keys. Only flags set on the command line apply,
+// so explicit flags take precedence over config files while defaults of flags do not clear configured keys.
+// Drivers like singlechecker parse the flags by their own flag set, so flags with non-default values apply, too.
+func mergeFlags(cfg *Config, flags *flag.FlagSet) error {
+	v := reflect.ValueOf(cfg).Elem()
+	fields := configFields()
+	set := map[string]bool{}
+	flags.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	var err error
+	flags.VisitAll(func(f *flag.Flag) {
+		i, ok := fields[f.Name]
+		if !ok || err != nil || !set[f.Name] && f.Value.String() == f.DefValue {
+			return
+		}
+		field := v.Field(i)
+		field.SetZero()
+		if derr := decodeConfigValue(field, strings.TrimSpace(f.Value.String())); derr != nil {
+			err = fmt.Errorf("-%s: %w", f.Name, derr)
+		}
+	})
+	return err
+}
+
+// reportConfigWarnings reports misconfiguration once per module, at the package clause of the first analyzed package
+// of the module. Modules of dependencies are skipped, as drivers do not show their diagnostics.
+func (c *checker) reportConfigWarnings() {
+	if len(c.Files) == 0 || isDependencyModule(c.module.root) || !c.module.reported.CompareAndSwap(false, true) {
+		return
+	}
+
+	warnings := slices.Clone(c.warnings)
+	for _, name := range c.unmatchedStructs() {
+		warnings = append(warnings, fmt.Sprintf("protected struct %s matches no type", name))
+	}
+	for _, warning := range warnings {
+		c.report(Issue{Pos: c.Files[0].Package, Category: categoryConfig, Message: warning})
+	}
+}
+
+// unmatchedStructs returns configured struct names which match no type declared in the module
+// nor in the analyzed package and its dependencies. Qualified names must match the package path as well.
+func (c *checker) unmatchedStructs() []string {
+	declared := maps.Clone(c.module.types)
+	if declared == nil {
+		declared = map[string]bool{}
+	}
+
+	visited := map[*types.Package]bool{}
+	var visit func(pkg *types.Package)
+	visit = func(pkg *types.Package) {
+		if visited[pkg] {
+			return
+		}
+		visited[pkg] = true
+		for _, name := range pkg.Scope().Names() {
+			if _, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok {
+				declared[name] = true
+				declared[pkg.Path()+"."+name] = true
+			}
+		}
+		for _, imp := range pkg.Imports() {
+			visit(imp)
+		}
+	}
+	visit(c.Pkg)
+
+	var out []string
+	for _, name := range c.Structs {
+		if name = strings.TrimSpace(name); name != "" && !declared[name] {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// moduleTypeNames returns simple and fully-qualified names of types declared in non-test files of the module.
+func moduleTypeNames(root string) map[string]bool {
+	names := map[string]bool{}
+	walkModuleFiles(root, func(pkgPath string, f *ast.File) {
+		for _, decl := range f.Decls {
+			if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+				for _, spec := range gd.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						names[ts.Name.Name] = true
+						names[pkgPath+"."+ts.Name.Name] = true
+					}
+				}
+			}
+		}
+	})
+	return names
+}
+
+// checkEmptyProtectedStructs reports structs of the analyzed package protected by name which have no exported fields,
+// as there is nothing to protect.
+func (c *checker) checkEmptyProtectedStructs() {
+	if c.protectAll {
+		return
+	}
+	scope := c.Pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() || !c.protectedByName(c.Pkg.Path()+"."+name, name) {
+			continue
+		}
+		s, ok := tn.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		exported := false
+		for i := range s.NumFields() {
+			exported = exported || s.Field(i).Exported()
+		}
+		if !exported {
+			c.report(Issue{
+				Pos:      tn.Pos(),
+				Message:  fmt.Sprintf("protected struct %s has no exported fields", name),
+				Category: categoryConfig,
+				Struct:   name,
+			})
+		}
+	}
+}
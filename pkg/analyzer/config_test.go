@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestDecodeConfig(t *testing.T) {
+	got, err := DecodeConfig(map[string]any{
+		"entity-list-file": "internal/entities.go",
+		"structs":          []any{"User", "Order"},
+		"fieldWriters":     map[string]any{"Order.Status": []any{"Submit", "Cancel"}},
+		"guarded-fields":   map[any]any{"Counter.Count": "mu"},
+		"implements":       []string{"github.com/acme/ddd.Entity"},
+		"callers":          "OrderLine.ApplyDiscount=Order",
+		"eventroots":       []any{"Order"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Config{
+		EntityListFile: []string{"internal/entities.go"},
+		Structs:        []string{"User", "Order"},
+		FieldWriters:   map[string][]string{"Order.Status": {"Submit", "Cancel"}},
+		GuardedFields:  map[string][]string{"Counter.Count": {"mu"}},
+		Implements:     []string{"github.com/acme/ddd.Entity"},
+		Callers:        map[string][]string{"OrderLine.ApplyDiscount": {"Order"}},
+		EventRoots:     []string{"Order"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeConfig() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeConfigErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		settings map[string]any
+		want     error
+		contains string
+	}{
+		"unknown key":     {settings: map[string]any{"struct": []any{"User"}}, want: ErrUnknownConfigKey, contains: "struct"},
+		"wrong type":      {settings: map[string]any{"structs": 1}, want: ErrInvalidConfigValue, contains: "structs"},
+		"wrong item type": {settings: map[string]any{"structs": []any{"User", 1}}, want: ErrInvalidConfigValue, contains: "structs"},
+		"wrong map value": {
+			settings: map[string]any{"aggregates": map[string]any{"Order": map[string]any{}}},
+			want:     ErrInvalidConfigValue,
+			contains: "aggregates: Order",
+		},
+		"wrong map type": {settings: map[string]any{"callers": []any{"Order"}}, want: ErrInvalidConfigValue, contains: "callers"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecodeConfig(tc.settings)
+			if !errors.Is(err, tc.want) || !strings.Contains(err.Error(), tc.contains) {
+				t.Errorf("DecodeConfig() error = %v, want %v containing %q", err, tc.want, tc.contains)
+			}
+		})
+	}
+}
+
+func TestInvalidConfigIsReported(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		"structs": 1,
+	}
+
+	rec := &errorRecorder{}
+	analysistest.Run(rec, testdata, NewAnalyzer(cfg), "configwarn")
+	if len(rec.errors) == 0 || !strings.Contains(rec.errors[0], ErrInvalidConfigValue.Error()) {
+		t.Errorf("expected invalid config value error, got: %v", rec.errors)
+	}
+}
+
+func TestConfigWarnings(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		entityListFileArg: filepath.Join(testdata, "src/config4/entities.go"),
+		structsArg:        []any{"Order", "Empty", "Missing", "configwarn.Order", "other.Order"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "configwarn")
+}
@@ -0,0 +1,187 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const configFileName = ".propro.yaml"
+
+var ErrConfigFile = errors.New("config file cannot be read")
+
+// golangciConfigNames are names of golangci-lint config files whose propro settings are used
+// when there is no .propro.yaml.
+var golangciConfigNames = []string{".golangci.yml", ".golangci.yaml"}
+
+// golangciSections are paths of the propro settings in golangci-lint config files: v1, v2 and the module plugin.
+var golangciSections = [][]string{
+	{"linters-settings", "propro"},
+	{"linters", "settings", "propro"},
+	{"linters", "settings", "custom", "propro", "settings"},
+}
+
+// FindConfig discovers the configuration of the standalone CLI for a package directory. All .propro.yaml files from
+// the directory up to the filesystem root are merged, keys of files nearer to the directory overriding keys of outer
+// ones. Maps like field-writers are merged by their entries. If there is no .propro.yaml, the propro settings of the
+// nearest .golangci.yml are used, so the CLI and golangci-lint share one configuration.
+// Relative entity list file paths are resolved against the directory of the file declaring them.
+func FindConfig(dir string) (map[string]any, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigFile, err)
+	}
+
+	var files []string
+	for _, d := range parentDirs(dir) {
+		if file := filepath.Join(d, configFileName); isRegularFile(file) {
+			files = append(files, file)
+		}
+	}
+	if len(files) == 0 {
+		return findGolangciConfig(dir)
+	}
+
+	out := map[string]any{}
+	for _, file := range slices.Backward(files) {
+		var settings map[string]any
+		if err := readYAML(file, &settings); err != nil {
+			return nil, err
+		}
+		mergeSettings(out, resolveConfigPaths(settings, filepath.Dir(file)))
+	}
+	return out, nil
+}
+
+// findGolangciConfig returns the propro settings of the nearest golangci-lint config file, or empty settings.
+func findGolangciConfig(dir string) (map[string]any, error) {
+	for _, d := range parentDirs(dir) {
+		for _, name := range golangciConfigNames {
+			file := filepath.Join(d, name)
+			if !isRegularFile(file) {
+				continue
+			}
+			var config map[string]any
+			if err := readYAML(file, &config); err != nil {
+				return nil, err
+			}
+			for _, path := range golangciSections {
+				section, ok := lookupSection(config, path)
+				if !ok {
+					continue
+				}
+				settings, ok := section.(map[string]any)
+				if !ok && section != nil {
+					return nil, fmt.Errorf("%w: %s: %s is not a map", ErrConfigFile, file, strings.Join(path, "."))
+				}
+				if settings == nil {
+					settings = map[string]any{}
+				}
+				return resolveConfigPaths(settings, d), nil
+			}
+			return map[string]any{}, nil
+		}
+	}
+	return map[string]any{}, nil
+}
+
+func readYAML(file string, out any) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrConfigFile, err)
+	}
+	if err := yaml.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrConfigFile, file, err)
+	}
+	return nil
+}
+
+// lookupSection returns the value at the path of keys in nested maps.
+func lookupSection(config map[string]any, path []string) (any, bool) {
+	var value any = config
+	for _, key := range path {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// mergeSettings merges src into dst. Keys are compared like config keys, so entity-list-file overrides entityListFile.
+func mergeSettings(dst, src map[string]any) {
+	for key, value := range src {
+		for existing, old := range dst {
+			if configKey(existing) != configKey(key) {
+				continue
+			}
+			delete(dst, existing)
+			oldMap, oldOK := old.(map[string]any)
+			newMap, newOK := value.(map[string]any)
+			if oldOK && newOK {
+				merged := maps.Clone(oldMap)
+				maps.Copy(merged, newMap)
+				value = merged
+			}
+		}
+		dst[key] = value
+	}
+}
+
+// resolveConfigPaths makes relative entity list file paths absolute against the directory of the config file.
+func resolveConfigPaths(settings map[string]any, dir string) map[string]any {
+	for key, value := range settings {
+		if configKey(key) != configKey(entityListFileArg) {
+			continue
+		}
+		var paths []any
+		switch val := value.(type) {
+		case string:
+			for _, path := range splitList(val) {
+				paths = append(paths, path)
+			}
+		case []any:
+			paths = val
+		default:
+			continue
+		}
+		resolved := make([]any, 0, len(paths))
+		for _, path := range paths {
+			if s, ok := path.(string); ok && !filepath.IsAbs(s) {
+				path = filepath.Join(dir, s)
+			}
+			resolved = append(resolved, path)
+		}
+		settings[key] = resolved
+	}
+	return settings
+}
+
+// configKey normalizes config keys, which may be kebab-case or camelCase of any letter case.
+func configKey(key string) string {
+	return strings.ToLower(camelCase(key))
+}
+
+// parentDirs returns the directory and all its parents up to the filesystem root.
+func parentDirs(dir string) []string {
+	out := []string{dir}
+	for parent := filepath.Dir(dir); parent != dir; parent = filepath.Dir(dir) {
+		out = append(out, parent)
+		dir = parent
+	}
+	return out
+}
+
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFindConfigMergesHierarchically(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		".propro.yaml": `entity-list-file: internal/entities.go
+structs: [User]
+field-writers:
+  Order.Status: [Submit]
+  Order.Total: [Recalculate]
+`,
+		"services/.propro.yaml": `structs: [Order]
+fieldWriters:
+  Order.Total: [AddLine]
+`,
+		".golangci.yml": "linters-settings:\n  propro:\n    structs: [Ignored]\n",
+	})
+
+	got, err := FindConfig(filepath.Join(root, "services/orders"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{
+		"entity-list-file": []any{filepath.Join(root, "internal/entities.go")},
+		"structs":          []any{"Order"},
+		"fieldWriters": map[string]any{
+			"Order.Status": []any{"Submit"},
+			"Order.Total":  []any{"AddLine"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindConfig() = %v, want %v", got, want)
+	}
+	if _, err := DecodeConfig(got); err != nil {
+		t.Errorf("DecodeConfig() error = %v", err)
+	}
+}
+
+func TestFindConfigFromGolangci(t *testing.T) {
+	for name, content := range map[string]string{
+		"v1": "linters-settings:\n  propro:\n    entity-list-file: entities.go\n",
+		"v2": "version: \"2\"\nlinters:\n  settings:\n    propro:\n      entity-list-file: entities.go\n",
+		"plugin": "linters:\n  settings:\n    custom:\n      propro:\n" +
+			"        type: module\n        settings:\n          entity-list-file: entities.go\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, map[string]string{".golangci.yml": content})
+
+			got, err := FindConfig(filepath.Join(root, "pkg"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := map[string]any{"entity-list-file": []any{filepath.Join(root, "entities.go")}}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("FindConfig() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFindConfigErrors(t *testing.T) {
+	for name, files := range map[string]map[string]string{
+		"invalid yaml":       {".propro.yaml": "structs: [User"},
+		"settings not a map": {".golangci.yml": "linters-settings:\n  propro: [User]\n"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, files)
+
+			if _, err := FindConfig(root); !errors.Is(err, ErrConfigFile) {
+				t.Errorf("FindConfig() error = %v, want %v", err, ErrConfigFile)
+			}
+		})
+	}
+}
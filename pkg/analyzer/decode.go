@@ -0,0 +1,97 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// builtinDecodeSinks lists decoding functions as "<full function name>:<destination argument index>".
+var builtinDecodeSinks = []string{
+	"encoding/json.Unmarshal:1",
+	"(*encoding/json.Decoder).Decode:0",
+	"encoding/xml.Unmarshal:1",
+	"(*encoding/xml.Decoder).Decode:0",
+	"(*encoding/xml.Decoder).DecodeElement:0",
+	"(*encoding/gob.Decoder).Decode:0",
+	"gopkg.in/yaml.v2.Unmarshal:1",
+	"gopkg.in/yaml.v3.Unmarshal:1",
+	"(*gopkg.in/yaml.v3.Decoder).Decode:0",
+	"sigs.k8s.io/yaml.Unmarshal:1",
+	"github.com/BurntSushi/toml.Unmarshal:1",
+	"github.com/mitchellh/mapstructure.Decode:1",
+	"github.com/mitchellh/mapstructure.WeakDecode:1",
+	"github.com/go-viper/mapstructure/v2.Decode:1",
+	"github.com/jinzhu/copier.Copy:0",
+	"github.com/jinzhu/copier.CopyWithOption:0",
+}
+
+// checkDecodeSink reports protected struct pointers passed as destination to decoding functions outside allowed packages.
+func (c *checker) checkDecodeSink(call *ast.CallExpr) {
+	if isAllowedPackage(c.Pkg, c.DecodeAllowedPackages) {
+		return
+	}
+	fn, ok := typeutil.Callee(c.TypesInfo, call).(*types.Func)
+	if !ok {
+		return
+	}
+	idx, ok := c.decodeSinks[fn.FullName()]
+	if !ok || idx >= len(call.Args) {
+		return
+	}
+
+	arg := call.Args[idx]
+	named := c.protectedDestination(c.TypesInfo.TypeOf(arg))
+	if named == nil {
+		return
+	}
+	c.reportIssuef(arg.Pos(), named.Obj().Name(), "*", categoryDecode,
+		"decoding into protected struct %s via %s is forbidden outside allowed packages", named.Obj().Name(), fn.FullName())
+}
+
+// protectedDestination returns the protected struct written through the destination type:
+// a pointer to the struct, or to a slice, array or map of the struct (pointers).
+func (c *checker) protectedDestination(t types.Type) *types.Named {
+	if _, ok := t.(*types.Pointer); !ok {
+		return nil
+	}
+	t = deref(t)
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		t = deref(u.Elem())
+	case *types.Array:
+		t = deref(u.Elem())
+	case *types.Map:
+		t = deref(u.Elem())
+	}
+	return c.protectedStruct(t)
+}
+
+// indexedFuncs parses the builtin and configured "<full function name>:<argument index>" lists of the config key
+// into a map; the index defaults to 0. A missing name or an index which is not a non-negative integer is an error.
+func indexedFuncs(key string, builtin, configured []string) (map[string]int, error) {
+	out := map[string]int{}
+	for _, spec := range slices.Concat(builtin, configured) {
+		name, idx, ok := strings.Cut(strings.TrimSpace(spec), ":")
+		var err error
+		i := 0
+		if ok {
+			i, err = strconv.Atoi(strings.TrimSpace(idx))
+		}
+		if name = strings.TrimSpace(name); name == "" || err != nil || i < 0 {
+			return nil, fmt.Errorf("%w: %s: %q, expected <function>:<argument index>", ErrInvalidConfigValue, key, spec)
+		}
+		out[name] = i
+	}
+	return out, nil
+}
+
+// isAllowedPackage checks whether the package is in the list (matched by import path or name).
+func isAllowedPackage(pkg *types.Package, allowed []string) bool {
+	return slices.Contains(allowed, pkg.Path()) || slices.Contains(allowed, pkg.Name())
+}
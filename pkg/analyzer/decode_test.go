@@ -0,0 +1,34 @@
+package analyzer
+
+import (
+	"errors"
+	"maps"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestDecodeSinks(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg:       []string{"User"},
+		decodeSinksArg:   []string{"codec.Decode:1"},
+		decodeAllowedArg: []string{"decoderepo"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "decode", "decoderepo")
+}
+
+func TestIndexedFuncs(t *testing.T) {
+	got, err := indexedFuncs(decodeSinksArg, []string{"encoding/json.Unmarshal:1"}, []string{" codec.Decode : 2 ", "codec.Read"})
+	want := map[string]int{"encoding/json.Unmarshal": 1, "codec.Decode": 2, "codec.Read": 0}
+	if err != nil || !maps.Equal(got, want) {
+		t.Errorf("indexedFuncs() = %v, %v, want %v", got, err, want)
+	}
+
+	for _, spec := range []string{"codec.Decode:dst", "codec.Decode:-1", "codec.Decode:", ":1"} {
+		if _, err := indexedFuncs(decodeSinksArg, nil, []string{spec}); !errors.Is(err, ErrInvalidConfigValue) {
+			t.Errorf("indexedFuncs(%q) error = %v, want %v", spec, err, ErrInvalidConfigValue)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+const directivePrefix = "//propro:"
+
+// parseDirectives collects //propro:<name> [args] comments from the given comment groups.
+// Arguments may be separated by commas and/or whitespace.
+func parseDirectives(groups ...*ast.CommentGroup) map[string][]string {
+	out := map[string][]string{}
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, c := range group.List {
+			if !strings.HasPrefix(c.Text, directivePrefix) {
+				continue
+			}
+			name, args, _ := strings.Cut(strings.TrimPrefix(c.Text, directivePrefix), " ")
+			name, inlineArgs, hasInlineArgs := strings.Cut(strings.TrimSpace(name), "=")
+			if hasInlineArgs {
+				args = inlineArgs + " " + args
+			}
+			out[name] = append(out[name], splitList(args)...)
+		}
+	}
+	return out
+}
+
+// splitList splits a comma and/or whitespace separated list, dropping empty items.
+func splitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+// directivesFact carries //propro: directives of a declaration (type, field, function or method)
+// so that they are honored also in packages importing the declaring one.
+type directivesFact struct {
+	Directives map[string][]string
+}
+
+func (*directivesFact) AFact() {}
+
+func (f *directivesFact) String() string {
+	return fmt.Sprintf("propro directives %v", f.Directives)
+}
+
+// exportDirectiveFacts exports directives found on declarations of the analyzed package as object facts.
+func (c *checker) exportDirectiveFacts() {
+	export := func(ident *ast.Ident, groups ...*ast.CommentGroup) {
+		obj := c.TypesInfo.Defs[ident]
+		if obj == nil {
+			return
+		}
+		if d := parseDirectives(groups...); len(d) > 0 {
+			c.ExportObjectFact(obj, &directivesFact{Directives: d})
+		}
+	}
+
+	for _, file := range c.Files {
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				export(d.Name, d.Doc)
+			case *ast.GenDecl:
+				exportTypeDirectiveFacts(d, export)
+			}
+		}
+	}
+}
+
+func exportTypeDirectiveFacts(gd *ast.GenDecl, export func(*ast.Ident, ...*ast.CommentGroup)) {
+	if gd.Tok != token.TYPE {
+		return
+	}
+	for _, spec := range gd.Specs {
+		ts, ok := spec.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+		doc := ts.Doc
+		if doc == nil && len(gd.Specs) == 1 {
+			doc = gd.Doc
+		}
+		export(ts.Name, doc, ts.Comment)
+
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			continue
+		}
+		for _, field := range st.Fields.List {
+			for _, name := range field.Names {
+				export(name, field.Doc, field.Comment)
+			}
+		}
+	}
+}
+
+// objectDirectives returns //propro: directives declared on the object, in any package.
+func (c *checker) objectDirectives(obj types.Object) map[string][]string {
+	if obj == nil || obj.Pkg() == nil {
+		return nil
+	}
+	var fact directivesFact
+	if !c.ImportObjectFact(obj, &fact) {
+		return nil
+	}
+	return fact.Directives
+}
@@ -0,0 +1,205 @@
+package analyzer
+
+import (
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// isProtectedStruct checks whether the named type is protected by name, by registration, by its package, by implementing
+// one of the Implements interfaces or by embedding one of the Embeds base types, directly or through other embedded
+// structs. Structs matching ExcludeStructs are never protected.
+func (c *checker) isProtectedStruct(named *types.Named) bool {
+	if c.excludedStruct(named) {
+		return false
+	}
+	if c.protectedByName(qualifiedName(named), named.Obj().Name()) {
+		return true
+	}
+	if (c.protectAll || c.inProtectedPackage(named)) && !(c.DTOHeuristic && looksLikeDTO(named)) {
+		return true
+	}
+	if len(c.Implements) == 0 && len(c.Embeds) == 0 {
+		return false
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return false
+	}
+
+	named = named.Origin()
+	if v, ok := c.protectedByType.Load(named); ok {
+		protected, _ := v.(bool)
+		return protected
+	}
+	implements, resolved := c.implementsAny(named)
+	protected := implements || c.embedsAny(named, map[*types.Named]bool{})
+	if protected || resolved {
+		c.protectedByType.Store(named, protected)
+	}
+	return protected
+}
+
+// protectedByName checks whether the struct of the qualified and simple name is configured, listed or registered.
+// Simple names match only unqualified config entries.
+func (c *checker) protectedByName(qualified, simple string) bool {
+	return c.protected[qualified] || c.protected[simple] || c.registered[qualified] || c.module.registered[qualified]
+}
+
+// implementsAny checks whether the struct implements one of the Implements interfaces. It also returns whether all
+// the interfaces are resolved, otherwise another package may still resolve them and the result must not be cached.
+func (c *checker) implementsAny(named *types.Named) (implements, resolved bool) {
+	resolved = true
+	for _, qualified := range c.Implements {
+		iface := c.lookupInterface(named, qualified)
+		if iface == nil {
+			resolved = false
+			continue
+		}
+		if implementsInterface(named, iface) {
+			return true, true
+		}
+	}
+	return false, resolved
+}
+
+// lookupInterface resolves the qualified interface name within the imports of the package of the struct, of the
+// analyzed package, or among the interfaces loaded for the module. Interfaces are satisfied implicitly, so neither
+// package has to import the interface.
+func (c *checker) lookupInterface(named *types.Named, qualified string) *types.Interface {
+	for _, pkg := range []*types.Package{named.Obj().Pkg(), c.Pkg} {
+		if iface, ok := lookupNamed(pkg, qualified).(*types.Interface); ok {
+			return iface
+		}
+	}
+	return c.module.interfaces[strings.TrimSpace(qualified)]
+}
+
+// implementsInterface checks whether the struct or a pointer to it implements the interface. Interfaces loaded for
+// the module do not share type identities with the analyzed packages, so their methods are compared by signatures
+// with package paths.
+func implementsInterface(named *types.Named, iface *types.Interface) bool {
+	ptr := types.NewPointer(named)
+	if types.Implements(named, iface) || types.Implements(ptr, iface) {
+		return true
+	}
+	methods := types.NewMethodSet(ptr)
+	for i := range iface.NumMethods() {
+		m := iface.Method(i)
+		if !m.Exported() {
+			return false
+		}
+		sel := methods.Lookup(nil, m.Name())
+		if sel == nil || types.TypeString(sel.Obj().Type(), nil) != types.TypeString(m.Type(), nil) {
+			return false
+		}
+	}
+	return true
+}
+
+// embedsAny walks the embedding graph of the struct looking for one of the Embeds base types.
+func (c *checker) embedsAny(named *types.Named, visited map[*types.Named]bool) bool {
+	if visited[named] {
+		return false
+	}
+	visited[named] = true
+
+	s, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := range s.NumFields() {
+		f := s.Field(i)
+		if !f.Embedded() {
+			continue
+		}
+		embedded, ok := deref(f.Type()).(*types.Named)
+		if !ok {
+			continue
+		}
+		embedded = embedded.Origin()
+		for _, qualified := range c.Embeds {
+			if qualifiedName(embedded) == strings.TrimSpace(qualified) {
+				return true
+			}
+		}
+		if c.embedsAny(embedded, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupNamed resolves a qualified type name like github.com/acme/ddd.Entity to its underlying type
+// within the package and its transitive imports, or returns nil.
+func lookupNamed(pkg *types.Package, qualified string) types.Type {
+	qualified = strings.TrimSpace(qualified)
+	i := strings.LastIndex(qualified, ".")
+	if pkg == nil || i < 0 {
+		return nil
+	}
+	path, name := qualified[:i], qualified[i+1:]
+
+	visited := map[*types.Package]bool{}
+	queue := []*types.Package{pkg}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if visited[p] {
+			continue
+		}
+		visited[p] = true
+		if p.Path() == path {
+			if obj, ok := p.Scope().Lookup(name).(*types.TypeName); ok {
+				return obj.Type().Underlying()
+			}
+			return nil
+		}
+		queue = append(queue, p.Imports()...)
+	}
+	return nil
+}
+
+// qualifiedName returns the type name qualified by its package path, e.g. github.com/acme/ddd.AggregateRoot.
+func qualifiedName(named *types.Named) string {
+	if named.Obj().Pkg() == nil {
+		return named.Obj().Name()
+	}
+	return named.Obj().Pkg().Path() + "." + named.Obj().Name()
+}
+
+// loadInterfaces loads the interfaces of the qualified names from packages resolvable in the module, for structs
+// neither whose package nor the analyzed package imports them. Names which cannot be loaded are skipped.
+func loadInterfaces(root string, qualified []string) map[string]*types.Interface {
+	names := map[string][]string{}
+	for _, q := range qualified {
+		q = strings.TrimSpace(q)
+		if i := strings.LastIndex(q, "."); i > 0 {
+			names[q[:i]] = append(names[q[:i]], q[i+1:])
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	// Loaded from source like entity list files, which does not depend on the export data format of the toolchain.
+	pkgs, err := packages.Load(&packages.Config{Mode: entityListLoadMode, Dir: root}, slices.Sorted(maps.Keys(names))...)
+	if err != nil {
+		return nil
+	}
+	out := map[string]*types.Interface{}
+	for _, pkg := range pkgs {
+		if pkg.Types == nil || len(pkg.Errors) > 0 {
+			continue
+		}
+		for _, name := range names[pkg.PkgPath] {
+			if obj, ok := pkg.Types.Scope().Lookup(name).(*types.TypeName); ok {
+				if iface, ok := obj.Type().Underlying().(*types.Interface); ok {
+					out[pkg.PkgPath+"."+name] = iface
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,26 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestImplementsAndEmbeds(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		implementsArg: "ddd.Entity",
+		embedsArg:     []string{"ddd.AggregateRoot"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "entities")
+}
+
+func TestImplementsWithoutImport(t *testing.T) {
+	cfg := map[string]any{
+		implementsArg: "example.com/implements/ddd.Entity",
+	}
+
+	analysistest.Run(t, filepath.Join(testdataDir(), "implements"), NewAnalyzer(cfg), "./handler")
+}
@@ -0,0 +1,278 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// entityListLoadMode loads dependencies from source, so lists and functions of imported packages can be resolved.
+const entityListLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax |
+	packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps
+
+var (
+	ErrEntityListNotFound   = errors.New("entity list file not found")
+	ErrEntityListLoad       = errors.New("entity list file cannot be loaded")
+	ErrEntityListVar        = errors.New("entity list variable not found")
+	ErrEntityListExpression = errors.New("entity list expression cannot be resolved")
+)
+
+// entityListLoader type-checks entity list files and resolves their variables to fully-qualified struct names.
+type entityListLoader struct {
+	pkgs      map[*types.Package]*packages.Package // the entity list package and its dependencies
+	resolving map[types.Object]bool                // variables and functions being resolved, to break recursion
+}
+
+// loadEntityList loads the entity list files (paths or globs) and returns fully-qualified names of the structs
+// listed by the variables, e.g. var EntityList = []any{&users.User{}, new(orders.Order)}.
+func loadEntityList(patterns, varNames []string) (map[string]bool, error) {
+	if len(varNames) == 0 {
+		varNames = []string{entityListVarName}
+	}
+
+	out := map[string]bool{}
+	for _, pattern := range patterns {
+		files, err := filepath.Glob(strings.TrimSpace(pattern))
+		if err != nil || len(files) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrEntityListNotFound, pattern)
+		}
+		for _, file := range files {
+			l := &entityListLoader{pkgs: map[*types.Package]*packages.Package{}, resolving: map[types.Object]bool{}}
+			names, err := l.loadFile(file, varNames)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range names {
+				out[name] = true
+			}
+		}
+	}
+	return out, nil
+}
+
+func (l *entityListLoader) loadFile(file string, varNames []string) ([]string, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrEntityListLoad, file, err)
+	}
+	pkg, err := l.load(filepath.Dir(abs))
+	if err != nil {
+		return nil, err
+	}
+	i := slices.Index(pkg.CompiledGoFiles, abs)
+	if i < 0 || i >= len(pkg.Syntax) {
+		return nil, fmt.Errorf("%w: %s is not a Go file of package %s", ErrEntityListLoad, file, pkg.PkgPath)
+	}
+
+	var out []string
+	found := false
+	for _, f := range pkg.Syntax[i : i+1] {
+		for _, spec := range valueSpecs(f) {
+			for i, name := range spec.Names {
+				if !slices.Contains(varNames, name.Name) || i >= len(spec.Values) {
+					continue
+				}
+				found = true
+				names, err := l.resolveList(pkg, spec.Values[i])
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, names...)
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s in %s", ErrEntityListVar, strings.Join(varNames, " or "), file)
+	}
+	return out, nil
+}
+
+// load loads the package in the directory with its dependencies, reporting any errors.
+func (l *entityListLoader) load(dir string) (*packages.Package, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: entityListLoadMode, Dir: dir}, ".")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrEntityListLoad, dir, err)
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("%w: %s: no package", ErrEntityListLoad, dir)
+	}
+
+	var msgs []string
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		l.pkgs[p.Types] = p
+		for _, e := range p.Errors {
+			msgs = append(msgs, e.Error())
+		}
+	})
+	if len(msgs) > 0 {
+		return nil, fmt.Errorf("%w: %s: %s", ErrEntityListLoad, dir, strings.Join(msgs, "; "))
+	}
+	return pkgs[0], nil
+}
+
+// resolveList resolves an expression evaluating to a list of entities: a composite literal, append(...),
+// a variable initialized by such expression or a call of a function returning it.
+func (l *entityListLoader) resolveList(pkg *packages.Package, expr ast.Expr) ([]string, error) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CompositeLit:
+		var out []string
+		for _, elt := range e.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			name, err := l.resolveEntity(pkg, elt)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, name)
+		}
+		return out, nil
+	case *ast.CallExpr:
+		switch callee := typeutil.Callee(pkg.TypesInfo, e).(type) {
+		case *types.Builtin:
+			if callee.Name() == "append" {
+				return l.resolveAppend(pkg, e)
+			}
+		case *types.Func:
+			return l.resolveFuncResult(callee)
+		}
+	case *ast.Ident, *ast.SelectorExpr:
+		if v, ok := pkg.TypesInfo.ObjectOf(identOf(e)).(*types.Var); ok {
+			return l.resolveVar(pkg, v)
+		}
+	}
+	return nil, l.unresolved(pkg, expr)
+}
+
+func (l *entityListLoader) resolveAppend(pkg *packages.Package, call *ast.CallExpr) ([]string, error) {
+	var out []string
+	for i, arg := range call.Args {
+		var names []string
+		var err error
+		if i == 0 || (call.Ellipsis.IsValid() && i == len(call.Args)-1) {
+			names, err = l.resolveList(pkg, arg)
+		} else {
+			var name string
+			name, err = l.resolveEntity(pkg, arg)
+			names = []string{name}
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, names...)
+	}
+	return out, nil
+}
+
+// resolveEntity returns the fully-qualified name of the struct allocated by &T{}, T{}, new(T) or T[int]{}.
+func (l *entityListLoader) resolveEntity(pkg *packages.Package, expr ast.Expr) (string, error) {
+	named, ok := deref(pkg.TypesInfo.TypeOf(expr)).(*types.Named)
+	if !ok {
+		return "", l.unresolved(pkg, expr)
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return "", l.unresolved(pkg, expr)
+	}
+	return qualifiedName(named.Origin()), nil
+}
+
+// resolveVar resolves the initializer of a variable declared in the package or in another package.
+func (l *entityListLoader) resolveVar(pkg *packages.Package, v *types.Var) ([]string, error) {
+	declPkg, ok := l.pkgs[v.Pkg()]
+	if !ok || l.resolving[v] {
+		return nil, nil
+	}
+	l.resolving[v] = true
+	defer delete(l.resolving, v)
+	for _, f := range declPkg.Syntax {
+		if init := initializer(declPkg.TypesInfo, f, v); init != nil {
+			return l.resolveList(declPkg, init)
+		}
+	}
+	return nil, fmt.Errorf("%w: variable %s has no initializer", ErrEntityListExpression, v.Name())
+}
+
+// resolveFuncResult resolves the first result of return statements of the function.
+func (l *entityListLoader) resolveFuncResult(fn *types.Func) ([]string, error) {
+	declPkg, ok := l.pkgs[fn.Pkg()]
+	if !ok || l.resolving[fn] {
+		return nil, nil
+	}
+	l.resolving[fn] = true
+	defer delete(l.resolving, fn)
+	var out []string
+	for _, f := range declPkg.Syntax {
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil || declPkg.TypesInfo.Defs[fd.Name] != fn {
+				continue
+			}
+			var errs []error
+			inspectOutsideFuncLits(fd.Body, func(n ast.Node) {
+				if ret, ok := n.(*ast.ReturnStmt); ok && len(ret.Results) > 0 {
+					names, err := l.resolveList(declPkg, ret.Results[0])
+					errs = append(errs, err)
+					out = append(out, names...)
+				}
+			})
+			return out, errors.Join(errs...)
+		}
+	}
+	return nil, fmt.Errorf("%w: function %s has no body", ErrEntityListExpression, fn.FullName())
+}
+
+func (l *entityListLoader) unresolved(pkg *packages.Package, expr ast.Expr) error {
+	return fmt.Errorf("%w: %s at %s", ErrEntityListExpression, types.ExprString(expr), pkg.Fset.Position(expr.Pos()))
+}
+
+// initializer returns the expression initializing the variable in a var declaration or a short variable declaration.
+func initializer(info *types.Info, f *ast.File, obj types.Object) ast.Expr {
+	var init ast.Expr
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if info.Defs[name] == obj && i < len(n.Values) && len(n.Names) == len(n.Values) {
+					init = n.Values[i]
+				}
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && info.Defs[id] == obj && len(n.Lhs) == len(n.Rhs) {
+					init = n.Rhs[i]
+				}
+			}
+		}
+		return init == nil
+	})
+	return init
+}
+
+func valueSpecs(f *ast.File) []*ast.ValueSpec {
+	var out []*ast.ValueSpec
+	for _, decl := range f.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok {
+			for _, spec := range gd.Specs {
+				if vs, ok := spec.(*ast.ValueSpec); ok {
+					out = append(out, vs)
+				}
+			}
+		}
+	}
+	return out
+}
+
+func identOf(expr ast.Expr) *ast.Ident {
+	if sel, ok := expr.(*ast.SelectorExpr); ok {
+		return sel.Sel
+	}
+	id, _ := expr.(*ast.Ident)
+	return id
+}
@@ -0,0 +1,56 @@
+package analyzer
+
+import (
+	"errors"
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadEntityList(t *testing.T) {
+	testdata := testdataDir()
+	const pkg = "github.com/digitalstraw/propro/v2/testdata/src/entitylist"
+
+	got, err := loadEntityList([]string{
+		filepath.Join(testdata, "src/entitylist/*.go"),
+		filepath.Join(testdata, "src/entitylist/users/users.go"),
+	}, []string{"Models", "Extra", "Entities"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		pkg + ".Audit",
+		pkg + "/orders.Line",
+		pkg + "/orders.Order",
+		pkg + "/users.Box",
+		pkg + "/users.Profile",
+		pkg + "/users.User",
+	}
+	if names := slices.Sorted(maps.Keys(got)); !slices.Equal(names, want) {
+		t.Errorf("loadEntityList() = %v, want %v", names, want)
+	}
+}
+
+func TestLoadEntityListErrors(t *testing.T) {
+	testdata := testdataDir()
+
+	for name, tc := range map[string]struct {
+		file     string
+		varNames []string
+		want     error
+	}{
+		"missing file":     {file: "src/config/missing.go", want: ErrEntityListNotFound},
+		"not a Go file":    {file: "src/config3/entities.go.txt", want: ErrEntityListLoad},
+		"missing variable": {file: "src/config4/entities.go", varNames: []string{"Models"}, want: ErrEntityListVar},
+		"unresolvable":     {file: "src/entitylist/broken/broken.go", want: ErrEntityListExpression},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := loadEntityList([]string{filepath.Join(testdata, tc.file)}, tc.varNames)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("loadEntityList() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
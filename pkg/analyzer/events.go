@@ -0,0 +1,191 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+
+	gocfg "golang.org/x/tools/go/cfg"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	// eventsDirective marks an aggregate root whose mutating methods must record domain events: //propro:events.
+	eventsDirective = "events"
+	// noEventDirective exempts a mutating method from recording an event: //propro:no-event <justification>.
+	noEventDirective = "no-event"
+
+	defaultEventRecorder = "Record"
+)
+
+// pathState tracks, along a control flow path, whether a protected field was written and whether an event was recorded.
+type pathState struct {
+	mutated  bool
+	recorded bool
+}
+
+// checkEventRecording reports exported methods of event-recording aggregate roots which write a protected field
+// and do not record a domain event on some return path.
+func (c *checker) checkEventRecording() {
+	for _, file := range c.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil || !fn.Name.IsExported() {
+				continue
+			}
+			recvObj := c.receiverObject(fn)
+			if recvObj == nil {
+				continue
+			}
+			named, ok := deref(recvObj.Type()).(*types.Named)
+			if !ok || !c.recordsEvents(named) {
+				continue
+			}
+			c.checkMethodRecordsEvents(fn, named, recvObj)
+		}
+	}
+}
+
+func (c *checker) checkMethodRecordsEvents(fn *ast.FuncDecl, named *types.Named, recvObj types.Object) {
+	if justification, ok := c.objectDirectives(c.TypesInfo.Defs[fn.Name])[noEventDirective]; ok {
+		if len(justification) == 0 {
+			c.reportIssuef(fn.Name.Pos(), named.Obj().Name(), fn.Name.Name, categoryEvents,
+				"//propro:%s directive of method %s.%s requires a justification", noEventDirective, named.Obj().Name(), fn.Name.Name)
+		}
+		return
+	}
+
+	g := gocfg.New(fn.Body, c.mayReturn())
+	transfer := func(b *gocfg.Block, st pathState) pathState {
+		for _, n := range b.Nodes {
+			c.inspectReceiverWrites(n, recvObj, func(target ast.Expr) {
+				if c.isProtectedFieldTarget(target) {
+					st.mutated = true
+				}
+			})
+			st.recorded = st.recorded || c.recordsEvent(n)
+		}
+		return st
+	}
+
+	if slices.ContainsFunc(exitStates(c, g, pathState{}, transfer), func(st pathState) bool { return st.mutated && !st.recorded }) {
+		c.reportIssuef(fn.Name.Pos(), named.Obj().Name(), fn.Name.Name, categoryEvents,
+			"method %s.%s writes protected fields without recording a domain event on some path", named.Obj().Name(), fn.Name.Name)
+	}
+}
+
+// exitStates explores all paths of the control flow graph, applying transfer to the path state in each block,
+// and returns path states reachable at return points. The state type must have a finite domain.
+func exitStates[S comparable](c *checker, g *gocfg.CFG, initial S, transfer func(*gocfg.Block, S) S) []S {
+	type visit struct {
+		block *gocfg.Block
+		state S
+	}
+
+	var out []S
+	visited := map[visit]bool{}
+	queue := []visit{{block: g.Blocks[0], state: initial}}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		if visited[v] {
+			continue
+		}
+		visited[v] = true
+
+		st := transfer(v.block, v.state)
+		if len(v.block.Succs) == 0 && !c.endsWithNoReturn(v.block) {
+			out = append(out, st)
+		}
+		for _, succ := range v.block.Succs {
+			queue = append(queue, visit{block: succ, state: st})
+		}
+	}
+	return out
+}
+
+// isProtectedFieldTarget checks whether the receiver write targets an exported field which is not an event recorder.
+func (c *checker) isProtectedFieldTarget(target ast.Expr) bool {
+	sel, ok := ast.Unparen(target).(*ast.SelectorExpr)
+	if !ok {
+		// *r = other
+		return true
+	}
+	return sel.Sel.IsExported() && !slices.Contains(c.eventRecorders(), sel.Sel.Name)
+}
+
+// recordsEvent checks whether the node calls an event recorder or assigns to an event recorder field.
+func (c *checker) recordsEvent(n ast.Node) bool {
+	recorders := c.eventRecorders()
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			found = found || slices.Contains(recorders, calleeName(node.Fun))
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				if sel, ok := ast.Unparen(lhs).(*ast.SelectorExpr); ok && slices.Contains(recorders, sel.Sel.Name) {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// calleeName returns the name of the called function or method.
+func calleeName(fun ast.Expr) string {
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		return f.Name
+	case *ast.SelectorExpr:
+		return f.Sel.Name
+	case *ast.IndexExpr:
+		return calleeName(f.X)
+	}
+	return ""
+}
+
+// recordsEvents checks whether the struct is configured or marked by //propro:events as an event-recording aggregate root.
+func (c *checker) recordsEvents(named *types.Named) bool {
+	if slices.Contains(c.EventRoots, named.Obj().Name()) {
+		return true
+	}
+	_, ok := c.objectDirectives(named.Obj())[eventsDirective]
+	return ok
+}
+
+// eventRecorders returns configured names of event-recording functions, methods and fields.
+func (c *checker) eventRecorders() []string {
+	if len(c.EventRecorders) == 0 {
+		return []string{defaultEventRecorder}
+	}
+	return c.EventRecorders
+}
+
+// mayReturn reports whether the call may return; calls to panic and os.Exit do not.
+func (c *checker) mayReturn() func(*ast.CallExpr) bool {
+	return func(call *ast.CallExpr) bool {
+		switch fn := typeutil.Callee(c.TypesInfo, call).(type) {
+		case *types.Builtin:
+			return fn.Name() != "panic"
+		case *types.Func:
+			return fn.FullName() != "os.Exit" && fn.FullName() != "log.Fatal" && fn.FullName() != "log.Fatalf"
+		}
+		return true
+	}
+}
+
+// endsWithNoReturn checks whether the block ends with a call which never returns.
+func (c *checker) endsWithNoReturn(b *gocfg.Block) bool {
+	if len(b.Nodes) == 0 {
+		return false
+	}
+	stmt, ok := b.Nodes[len(b.Nodes)-1].(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := stmt.X.(*ast.CallExpr)
+	return ok && !c.mayReturn()(call)
+}
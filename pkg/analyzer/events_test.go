@@ -0,0 +1,17 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestEventRecording(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		eventRootsArg:     []string{"Order"},
+		eventRecordersArg: []string{"Record", "events"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "events")
+}
@@ -0,0 +1,72 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Policies for generated files, see the generated config.
+const (
+	generatedSkip    = "skip"
+	generatedEnforce = "enforce"
+)
+
+// generatorRegexp matches the generator in a header like "// Code generated by stringer -type=Kind; DO NOT EDIT.".
+var generatorRegexp = regexp.MustCompile(`^// Code generated by "?([^\s;.,"]+)`)
+
+// validateGeneratedPolicy checks the generated code policy and the generator patterns.
+func validateGeneratedPolicy(cfg *Config) error {
+	if cfg.Generated != "" && cfg.Generated != generatedSkip && cfg.Generated != generatedEnforce {
+		return fmt.Errorf("%w: %s: %q, expected %s or %s", ErrInvalidConfigValue, generatedArg, cfg.Generated, generatedSkip, generatedEnforce)
+	}
+	for _, pattern := range cfg.GeneratedGenerators {
+		if _, err := path.Match(strings.ToLower(strings.TrimSpace(pattern)), ""); err != nil {
+			return fmt.Errorf("%w: %s: %q: %w", ErrInvalidConfigValue, generatorsArg, pattern, err)
+		}
+	}
+	return nil
+}
+
+// findSkippedGeneratedFiles finds generated files of the package whose issues are not reported: by default all of them,
+// or those of the GeneratedGenerators only. The files are still analyzed, so e.g. generated methods of a protected
+// struct count as its methods.
+func (c *checker) findSkippedGeneratedFiles() {
+	c.skipped = map[string]bool{}
+	if c.Generated == generatedEnforce {
+		return
+	}
+	for _, file := range c.Files {
+		if !ast.IsGenerated(file) {
+			continue
+		}
+		if len(c.GeneratedGenerators) == 0 || c.allowedGenerator(generator(file)) {
+			c.skipped[c.Fset.File(file.Package).Name()] = true
+		}
+	}
+}
+
+func (c *checker) allowedGenerator(name string) bool {
+	return slices.ContainsFunc(c.GeneratedGenerators, func(pattern string) bool {
+		ok, _ := path.Match(strings.ToLower(strings.TrimSpace(pattern)), strings.ToLower(name))
+		return ok
+	})
+}
+
+// generator returns the name of the generator of the generated file, e.g. stringer or MockGen, or empty string.
+func generator(file *ast.File) string {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if m := generatorRegexp.FindStringSubmatch(comment.Text); m != nil {
+				return m[1]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package analyzer
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestGeneratedFilesAreSkipped(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg: []string{"Order"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "generated")
+}
+
+func TestGeneratedFilesOfGenerators(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg:    []string{"Order"},
+		generatorsArg: []any{"sqlboiler"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "generatedby")
+}
+
+func TestGenerator(t *testing.T) {
+	for src, want := range map[string]string{
+		"// Code generated by stringer -type=Kind; DO NOT EDIT.\n\npackage p\n":     "stringer",
+		"// Code generated by MockGen. DO NOT EDIT.\n\npackage p\n":                 "MockGen",
+		"// Code generated by \"stringer -type=Kind\"; DO NOT EDIT.\n\npackage p\n": "stringer",
+		"// Code generated. DO NOT EDIT.\n\npackage p\n":                            "",
+	} {
+		f, err := parser.ParseFile(token.NewFileSet(), "p.go", src, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := generator(f); got != want {
+			t.Errorf("generator(%q) = %q, want %q", src, got, want)
+		}
+	}
+}
+
+func TestValidateGeneratedPolicy(t *testing.T) {
+	if err := validateGeneratedPolicy(&Config{Generated: "allow"}); !errors.Is(err, ErrInvalidConfigValue) {
+		t.Errorf("validateGeneratedPolicy() error = %v, want %v", err, ErrInvalidConfigValue)
+	}
+}
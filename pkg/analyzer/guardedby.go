@@ -0,0 +1,207 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+
+	gocfg "golang.org/x/tools/go/cfg"
+)
+
+// guardedByDirective declares the mutex guarding a field: //propro:guardedby mu.
+const guardedByDirective = "guardedby"
+
+// checkGuardedFieldOutsideMethods reports writes to mutex-guarded fields outside methods of their struct.
+// It returns true when the field is guarded, i.e. the write has been fully handled here or by checkGuardedWrites.
+func (c *checker) checkGuardedFieldOutsideMethods(sel *ast.SelectorExpr) bool {
+	owner, field := c.selectedField(sel)
+	if field == nil || c.guardingMutex(owner, field) == "" {
+		return false
+	}
+	if !c.insideStructMethod(sel.Pos(), owner.Obj().Name()) {
+		c.reportIssue(sel.Pos(), owner.Obj().Name(), field.Name())
+	}
+	return true
+}
+
+// checkGuardedWrites reports writes to mutex-guarded receiver fields in methods of the analyzed package
+// which are not dominated by Lock() of the guarding mutex without an intervening Unlock().
+func (c *checker) checkGuardedWrites() {
+	for _, file := range c.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			recvObj := c.receiverObject(fn)
+			if recvObj == nil {
+				continue
+			}
+			if named, ok := deref(recvObj.Type()).(*types.Named); ok && c.hasGuardedFields(named) {
+				c.checkGuardedWritesInBody(fn.Body, named, recvObj)
+			}
+		}
+	}
+}
+
+// checkGuardedWritesInBody runs a must-hold lock analysis over the body. Function literals are analyzed
+// separately, starting with no lock held, as they may run outside the enclosing critical section.
+func (c *checker) checkGuardedWritesInBody(body *ast.BlockStmt, named *types.Named, recvObj types.Object) {
+	g := gocfg.New(body, c.mayReturn())
+	for _, mutex := range c.guardingMutexes(named) {
+		held := c.lockHeldOnEntry(g, recvObj, mutex)
+		for _, b := range g.Blocks {
+			if !b.Live {
+				continue
+			}
+			locked := held[b.Index]
+			for _, n := range b.Nodes {
+				c.reportUnguardedWrites(n, named, recvObj, mutex, locked)
+				locked = c.lockStateAfter(n, recvObj, mutex, locked)
+			}
+		}
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.FuncLit); ok {
+			c.checkGuardedWritesInBody(lit.Body, named, recvObj)
+			return false
+		}
+		return true
+	})
+}
+
+func (c *checker) reportUnguardedWrites(n ast.Node, named *types.Named, recvObj types.Object, mutex string, locked bool) {
+	if locked {
+		return
+	}
+	inspectOutsideFuncLits(n, func(n ast.Node) {
+		for _, target := range c.receiverWriteTargets(n, recvObj) {
+			sel, ok := ast.Unparen(target).(*ast.SelectorExpr)
+			if !ok {
+				continue
+			}
+			owner, field := c.selectedField(sel)
+			if field == nil || owner != named || c.guardingMutex(owner, field) != mutex {
+				continue
+			}
+			c.reportIssuef(sel.Pos(), owner.Obj().Name(), field.Name(), categoryGuardedBy,
+				"assignment to field %s.%s requires %s.Lock() to be held", owner.Obj().Name(), field.Name(), mutex)
+		}
+	})
+}
+
+// lockHeldOnEntry computes, for each block, whether the mutex is held on entry on all paths.
+func (c *checker) lockHeldOnEntry(g *gocfg.CFG, recvObj types.Object, mutex string) []bool {
+	preds := make([][]*gocfg.Block, len(g.Blocks))
+	held := make([]bool, len(g.Blocks))
+	for _, b := range g.Blocks {
+		held[b.Index] = b.Index != 0
+		for _, succ := range b.Succs {
+			preds[succ.Index] = append(preds[succ.Index], b)
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, b := range g.Blocks {
+			if b.Index == 0 || !b.Live {
+				continue
+			}
+			in := len(preds[b.Index]) > 0
+			for _, p := range preds[b.Index] {
+				if p.Live {
+					in = in && c.lockStateAfterBlock(p, recvObj, mutex, held[p.Index])
+				}
+			}
+			if in != held[b.Index] {
+				held[b.Index] = in
+				changed = true
+			}
+		}
+	}
+	return held
+}
+
+func (c *checker) lockStateAfterBlock(b *gocfg.Block, recvObj types.Object, mutex string, locked bool) bool {
+	for _, n := range b.Nodes {
+		locked = c.lockStateAfter(n, recvObj, mutex, locked)
+	}
+	return locked
+}
+
+// lockStateAfter updates the lock state by recv.mutex.Lock() and recv.mutex.Unlock() calls within the node.
+// Deferred calls and calls in function literals do not change the state.
+func (c *checker) lockStateAfter(n ast.Node, recvObj types.Object, mutex string, locked bool) bool {
+	if _, ok := n.(*ast.DeferStmt); ok {
+		return locked
+	}
+	inspectOutsideFuncLits(n, func(n ast.Node) {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		mu, ok := ast.Unparen(sel.X).(*ast.SelectorExpr)
+		if !ok || mu.Sel.Name != mutex {
+			return
+		}
+		if root := rootIdent(mu.X); root == nil || c.TypesInfo.Uses[root] != recvObj {
+			return
+		}
+		switch sel.Sel.Name {
+		case "Lock":
+			locked = true
+		case "Unlock":
+			locked = false
+		}
+	})
+	return locked
+}
+
+// inspectOutsideFuncLits calls f for n and all its descendants except function literal bodies.
+func inspectOutsideFuncLits(n ast.Node, f func(ast.Node)) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		if n != nil {
+			f(n)
+		}
+		return true
+	})
+}
+
+// guardingMutex returns the name of the mutex guarding the field from config or the //propro:guardedby directive.
+func (c *checker) guardingMutex(owner *types.Named, field *types.Var) string {
+	if mutexes := c.GuardedFields[owner.Obj().Name()+"."+field.Name()]; len(mutexes) > 0 {
+		return mutexes[0]
+	}
+	if mutexes := c.objectDirectives(field)[guardedByDirective]; len(mutexes) > 0 {
+		return mutexes[0]
+	}
+	return ""
+}
+
+// guardingMutexes returns distinct names of mutexes guarding fields of the struct.
+func (c *checker) guardingMutexes(named *types.Named) []string {
+	s, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	var out []string
+	seenMutex := map[string]bool{}
+	for i := range s.NumFields() {
+		if mu := c.guardingMutex(named, s.Field(i)); mu != "" && !seenMutex[mu] {
+			seenMutex[mu] = true
+			out = append(out, mu)
+		}
+	}
+	return out
+}
+
+func (c *checker) hasGuardedFields(named *types.Named) bool {
+	return len(c.guardingMutexes(named)) > 0
+}
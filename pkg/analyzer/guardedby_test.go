@@ -0,0 +1,19 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestGuardedBy(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg: []string{"Other"},
+		guardedFieldsArg: map[string]any{
+			"Counter.Last": "mu",
+		},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "guardedby")
+}
@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"slices"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const (
+	// hiddenDirective marks a field which must not be read outside its struct methods: //propro:hidden.
+	hiddenDirective = "hidden"
+	// structTagKey is the struct tag key for field classification: `propro:"hidden"`.
+	structTagKey = "propro"
+)
+
+// loggingPackages are packages whose functions must never receive hidden field values.
+var loggingPackages = []string{"fmt", "log", "log/slog"}
+
+// checkHiddenFieldRead reports reads of hidden fields outside methods of their struct and allowed packages.
+// Write targets are left to mutation checks.
+func (c *checker) checkHiddenFieldRead(sel *ast.SelectorExpr, writeTargets map[ast.Expr]bool) {
+	if writeTargets[sel] {
+		return
+	}
+	owner, field := c.selectedField(sel)
+	if field == nil || !c.isHiddenField(owner, field) {
+		return
+	}
+	if c.insideStructMethod(sel.Pos(), owner.Obj().Name()) || isAllowedPackage(c.Pkg, c.HiddenFieldReaders) {
+		return
+	}
+	c.reportIssuef(sel.Pos(), owner.Obj().Name(), field.Name(), categoryHidden,
+		"read of hidden field %s.%s is forbidden outside its methods", owner.Obj().Name(), field.Name())
+}
+
+// checkHiddenFieldLogging reports hidden field values, and structs with hidden fields, passed to fmt, log or slog.
+// These are reported everywhere, even in methods of the struct and in allowed packages.
+func (c *checker) checkHiddenFieldLogging(call *ast.CallExpr) {
+	fn, ok := typeutil.Callee(c.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || !slices.Contains(loggingPackages, fn.Pkg().Path()) {
+		return
+	}
+
+	for _, arg := range call.Args {
+		if sel, ok := ast.Unparen(arg).(*ast.SelectorExpr); ok {
+			if owner, field := c.selectedField(sel); field != nil && c.isHiddenField(owner, field) {
+				c.reportIssuef(arg.Pos(), owner.Obj().Name(), field.Name(), categoryHidden,
+					"hidden field %s.%s must not be passed to %s", owner.Obj().Name(), field.Name(), fn.FullName())
+				continue
+			}
+		}
+		named, ok := deref(c.TypesInfo.TypeOf(arg)).(*types.Named)
+		if !ok {
+			continue
+		}
+		if field := c.firstHiddenField(named); field != nil {
+			c.reportIssuef(arg.Pos(), named.Obj().Name(), field.Name(), categoryHidden,
+				"%s with hidden field %s must not be passed to %s", named.Obj().Name(), field.Name(), fn.FullName())
+		}
+	}
+}
+
+// firstHiddenField returns the first hidden field of the struct, or nil.
+func (c *checker) firstHiddenField(named *types.Named) *types.Var {
+	s, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	for i := range s.NumFields() {
+		if c.isHiddenField(named, s.Field(i)) {
+			return s.Field(i)
+		}
+	}
+	return nil
+}
+
+// isHiddenField checks the field against the config, the `propro:"hidden"` tag and the //propro:hidden directive.
+func (c *checker) isHiddenField(owner *types.Named, field *types.Var) bool {
+	if slices.Contains(c.HiddenFields, owner.Obj().Name()+"."+field.Name()) {
+		return true
+	}
+	if s, ok := owner.Underlying().(*types.Struct); ok {
+		for i := range s.NumFields() {
+			if s.Field(i) == field && slices.Contains(splitList(reflect.StructTag(s.Tag(i)).Get(structTagKey)), hiddenDirective) {
+				return true
+			}
+		}
+	}
+	_, ok := c.objectDirectives(field)[hiddenDirective]
+	return ok
+}
@@ -0,0 +1,18 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestHiddenFields(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg:       []string{"User"},
+		hiddenFieldsArg:  []string{"User.APIKey"},
+		hiddenReadersArg: []string{"hiddenrepo"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "hidden", "hiddenuse", "hiddenrepo")
+}
@@ -0,0 +1,182 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+)
+
+const (
+	// immutableDirective marks a field which must not change after construction: //propro:immutable.
+	immutableDirective = "immutable"
+	// constructorDirective marks a function allowed to write immutable fields: //propro:constructor.
+	constructorDirective = "constructor"
+)
+
+// checkImmutableField reports writes to immutable fields made after construction.
+// It returns true when the write has been fully handled here: it is reported, or it is a construction write
+// in the package of the struct. Construction writes in other packages are left to the remaining checks,
+// so e.g. the protected check still forbids them.
+func (c *checker) checkImmutableField(sel *ast.SelectorExpr) bool {
+	owner, field := c.selectedField(sel)
+	if field == nil || !c.isImmutableField(owner, field) {
+		return false
+	}
+
+	fn := c.findEnclosingFunc(sel.Pos())
+	if c.isConstructor(fn, owner) || c.inConstructionPhase(fn, sel.X, sel.Pos()) {
+		return owner.Obj().Pkg() == c.Pkg
+	}
+
+	c.reportImmutableWrite(sel.Pos(), owner, field)
+	return true
+}
+
+// checkWholeStructOverwrite reports overwrites like *e = other which write every immutable field of the struct.
+func (c *checker) checkWholeStructOverwrite(lhs ast.Expr) {
+	star, ok := ast.Unparen(lhs).(*ast.StarExpr)
+	if !ok {
+		return
+	}
+	named, ok := deref(c.TypesInfo.TypeOf(star)).(*types.Named)
+	if !ok {
+		return
+	}
+	s, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+
+	fn := c.findEnclosingFunc(lhs.Pos())
+	if c.isConstructor(fn, named) || c.inConstructionPhase(fn, star.X, lhs.Pos()) {
+		return
+	}
+	for i := range s.NumFields() {
+		if field := s.Field(i); c.isImmutableField(named, field) {
+			c.reportImmutableWrite(lhs.Pos(), named, field)
+		}
+	}
+}
+
+func (c *checker) reportImmutableWrite(pos token.Pos, owner *types.Named, field *types.Var) {
+	c.reportIssuef(pos, owner.Obj().Name(), field.Name(), categoryImmutable,
+		"assignment to immutable field %s.%s is forbidden after construction", owner.Obj().Name(), field.Name())
+}
+
+// isImmutableField checks the field against the config and the //propro:immutable directive.
+func (c *checker) isImmutableField(owner *types.Named, field *types.Var) bool {
+	if slices.Contains(c.ImmutableFields, owner.Obj().Name()+"."+field.Name()) {
+		return true
+	}
+	_, ok := c.objectDirectives(field)[immutableDirective]
+	return ok
+}
+
+// isConstructor checks whether fn is a configured constructor or carries the //propro:constructor directive.
+func (c *checker) isConstructor(fn *ast.FuncDecl, owner *types.Named) bool {
+	if fn == nil {
+		return false
+	}
+	for _, spec := range c.Constructors {
+		if c.funcMatches(fn, spec, owner) {
+			return true
+		}
+	}
+	_, ok := c.objectDirectives(c.TypesInfo.Defs[fn.Name])[constructorDirective]
+	return ok
+}
+
+// inConstructionPhase checks whether target is rooted in a local variable freshly allocated in fn
+// (composite literal, new(T) or zero value declaration) which has not been used otherwise than
+// for writing its fields until pos.
+func (c *checker) inConstructionPhase(fn *ast.FuncDecl, target ast.Expr, pos token.Pos) bool {
+	root := rootIdent(target)
+	if fn == nil || fn.Body == nil || root == nil {
+		return false
+	}
+	obj := c.TypesInfo.ObjectOf(root)
+	if obj == nil || obj.Pos() < fn.Body.Pos() || obj.Pos() > fn.Body.End() || !c.isFreshlyAllocated(fn, obj) {
+		return false
+	}
+
+	writeRoots := map[*ast.Ident]bool{}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if assign, ok := n.(*ast.AssignStmt); ok {
+			for _, lhs := range assign.Lhs {
+				if sel, ok := ast.Unparen(lhs).(*ast.SelectorExpr); ok {
+					writeRoots[rootIdent(sel.X)] = true
+				}
+			}
+		}
+		return true
+	})
+
+	escaped := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if ok && id != root && id.Pos() < pos && c.TypesInfo.Uses[id] == obj && !writeRoots[id] {
+			escaped = true
+		}
+		return !escaped
+	})
+	return !escaped
+}
+
+// isFreshlyAllocated checks whether the local variable is defined in fn by a fresh allocation.
+func (c *checker) isFreshlyAllocated(fn *ast.FuncDecl, obj types.Object) bool {
+	fresh := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && c.TypesInfo.Defs[id] == obj && len(node.Rhs) == len(node.Lhs) {
+					fresh = c.isAllocation(node.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			for i, id := range node.Names {
+				if c.TypesInfo.Defs[id] != obj {
+					continue
+				}
+				fresh = len(node.Values) == 0 || (len(node.Values) == len(node.Names) && c.isAllocation(node.Values[i]))
+			}
+		}
+		return true
+	})
+	return fresh
+}
+
+// isAllocation checks for T{...}, &T{...} and new(T).
+func (c *checker) isAllocation(expr ast.Expr) bool {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CompositeLit:
+		return true
+	case *ast.UnaryExpr:
+		_, ok := ast.Unparen(e.X).(*ast.CompositeLit)
+		return ok && e.Op == token.AND
+	case *ast.CallExpr:
+		id, ok := ast.Unparen(e.Fun).(*ast.Ident)
+		if !ok {
+			return false
+		}
+		b, ok := c.TypesInfo.Uses[id].(*types.Builtin)
+		return ok && b.Name() == "new"
+	}
+	return false
+}
+
+// rootIdent returns the identifier at the root of selector, star and paren expressions.
+func rootIdent(expr ast.Expr) *ast.Ident {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		return rootIdent(e.X)
+	case *ast.StarExpr:
+		return rootIdent(e.X)
+	case *ast.ParenExpr:
+		return rootIdent(e.X)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestImmutableFields(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg:         []string{"Account"},
+		immutableFieldsArg: []any{"Account.TenantID", "Account.CreatedAt"},
+		constructorsArg:    []any{"NewAccount"},
+	}
+
+	results := analysistest.Run(t, testdata, NewAnalyzer(cfg), "immutable", "immutableuse")
+
+	for _, result := range results {
+		for _, d := range result.Diagnostics {
+			construction := result.Pass.Pkg.Path() == "immutableuse" && d.Category == categoryProtected
+			if d.Category != categoryImmutable && !construction {
+				t.Errorf("unexpected category %q of %q", d.Category, d.Message)
+			}
+		}
+	}
+}
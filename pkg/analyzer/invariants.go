@@ -0,0 +1,139 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+	"strings"
+
+	gocfg "golang.org/x/tools/go/cfg"
+)
+
+// invariantDirective marks the invariant method of a struct: //propro:invariant.
+const invariantDirective = "invariant"
+
+// invariantState tracks, along a control flow path, fields written since the last invariant check.
+type invariantState struct {
+	unchecked string // sorted, comma-separated field names
+	deferred  bool   // invariant check deferred to return
+}
+
+// checkInvariantHooks reports methods of protected structs which write a protected field
+// and may return without calling the invariant method of the struct afterwards.
+func (c *checker) checkInvariantHooks() {
+	for _, file := range c.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			recvObj := c.receiverObject(fn)
+			if recvObj == nil {
+				continue
+			}
+			named, ok := deref(recvObj.Type()).(*types.Named)
+			if !ok || !c.isProtectedStruct(named) {
+				continue
+			}
+			invariant := c.invariantMethod(named)
+			if invariant == "" || invariant == fn.Name.Name {
+				continue
+			}
+			c.checkMethodChecksInvariants(fn, named, recvObj, invariant)
+		}
+	}
+}
+
+func (c *checker) checkMethodChecksInvariants(fn *ast.FuncDecl, named *types.Named, recvObj types.Object, invariant string) {
+	transfer := func(b *gocfg.Block, st invariantState) invariantState {
+		for _, n := range b.Nodes {
+			fields := splitList(st.unchecked)
+			c.inspectReceiverWrites(n, recvObj, func(target ast.Expr) {
+				if name := receiverFieldName(target); ast.IsExported(name) || name == "*" {
+					fields = append(fields, name)
+				}
+			})
+			if _, isDefer := n.(*ast.DeferStmt); isDefer && c.callsReceiverMethod(n, recvObj, invariant) {
+				st.deferred = true
+			} else if c.callsReceiverMethod(n, recvObj, invariant) {
+				fields = nil
+			}
+			slices.Sort(fields)
+			st.unchecked = strings.Join(slices.Compact(fields), ",")
+		}
+		return st
+	}
+
+	var unchecked []string
+	for _, st := range exitStates(c, gocfg.New(fn.Body, c.mayReturn()), invariantState{}, transfer) {
+		if !st.deferred {
+			unchecked = append(unchecked, splitList(st.unchecked)...)
+		}
+	}
+	if len(unchecked) == 0 {
+		return
+	}
+	slices.Sort(unchecked)
+	unchecked = slices.Compact(unchecked)
+
+	structName := named.Obj().Name()
+	for i, field := range unchecked {
+		unchecked[i] = structName + "." + field
+	}
+	c.reportIssuef(fn.Name.Pos(), structName, fn.Name.Name, categoryInvariants,
+		"method %s.%s writes %s without calling %s before returning",
+		structName, fn.Name.Name, strings.Join(unchecked, ", "), invariant)
+}
+
+// invariantMethod returns the name of the configured or //propro:invariant marked method of the struct, or empty string.
+func (c *checker) invariantMethod(named *types.Named) string {
+	for i := range named.NumMethods() {
+		m := named.Method(i)
+		if slices.Contains(c.InvariantMethods, m.Name()) {
+			return m.Name()
+		}
+		if _, ok := c.objectDirectives(m)[invariantDirective]; ok {
+			return m.Name()
+		}
+	}
+	return ""
+}
+
+// receiverFieldName returns the name of the receiver field written by target, or "*" for whole value overwrites.
+func receiverFieldName(target ast.Expr) string {
+	switch e := ast.Unparen(target).(type) {
+	case *ast.SelectorExpr:
+		if _, ok := ast.Unparen(e.X).(*ast.Ident); ok {
+			return e.Sel.Name
+		}
+		if star, ok := ast.Unparen(e.X).(*ast.StarExpr); ok {
+			if _, ok := ast.Unparen(star.X).(*ast.Ident); ok {
+				return e.Sel.Name
+			}
+		}
+		return receiverFieldName(e.X)
+	case *ast.StarExpr:
+		if _, ok := ast.Unparen(e.X).(*ast.Ident); ok {
+			return "*"
+		}
+		return receiverFieldName(e.X)
+	}
+	return ""
+}
+
+// callsReceiverMethod checks whether the node calls the named method on the receiver.
+func (c *checker) callsReceiverMethod(n ast.Node, recvObj types.Object, method string) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return !found
+		}
+		if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok && sel.Sel.Name == method {
+			root := rootIdent(sel.X)
+			found = found || (root != nil && c.TypesInfo.Uses[root] == recvObj)
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,17 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestInvariantHooks(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg:          []string{"Order", "Customer"},
+		invariantMethodsArg: []string{"Validate"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "invariants")
+}
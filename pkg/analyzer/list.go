@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	gochecker "golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+var ErrListLoad = errors.New("packages cannot be loaded")
+
+// protectedStructs returns fully-qualified names of structs declared in the analyzed package which are protected.
+func (c *checker) protectedStructs() []string {
+	var out []string
+	scope := c.Pkg.Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+		named, ok := tn.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		if _, ok := named.Underlying().(*types.Struct); ok && c.isProtectedStruct(named) {
+			out = append(out, qualifiedName(named))
+		}
+	}
+	return out
+}
+
+// ListProtectedStructs loads the packages matching the patterns in the directory and returns fully-qualified names
+// of structs declared in them which are protected by the settings, to verify the effective protected set.
+func ListProtectedStructs(settings map[string]any, dir string, patterns ...string) ([]string, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir}, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrListLoad, err)
+	}
+	var msgs []string
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		for _, e := range p.Errors {
+			msgs = append(msgs, e.Error())
+		}
+	})
+	if len(msgs) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrListLoad, strings.Join(msgs, "; "))
+	}
+
+	graph, err := gochecker.Analyze([]*analysis.Analyzer{NewAnalyzer(settings)}, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			return nil, act.Err
+		}
+		if result, ok := act.Result.(*Result); ok {
+			out = append(out, result.Protected...)
+		}
+	}
+	slices.Sort(out)
+	return out, nil
+}
@@ -0,0 +1,209 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// builtinORMUpdates lists ORM update methods as "<full method name>:<columns argument index>".
+// The columns argument is a column or field name, a map keyed by them or a struct literal.
+var builtinORMUpdates = []string{
+	"(*gorm.io/gorm.DB).Update:0",
+	"(*gorm.io/gorm.DB).UpdateColumn:0",
+	"(*gorm.io/gorm.DB).Updates:0",
+	"(*gorm.io/gorm.DB).UpdateColumns:0",
+}
+
+// builtinORMModels lists ORM methods setting the model of the call chain as "<full method name>:<model argument index>".
+var builtinORMModels = []string{
+	"(*gorm.io/gorm.DB).Model:0",
+}
+
+// checkORMUpdate reports column-name or field-name ORM updates of protected entity models outside allowed packages.
+// The model is the protected struct passed to a model method within the call chain, e.g. db.Model(&user).Update("status", x).
+func (c *checker) checkORMUpdate(call *ast.CallExpr) {
+	if isAllowedPackage(c.Pkg, c.ORMAllowedPackages) {
+		return
+	}
+	fn, ok := typeutil.Callee(c.TypesInfo, call).(*types.Func)
+	if !ok {
+		return
+	}
+	idx, ok := c.ormUpdates[fn.FullName()]
+	if !ok || idx >= len(call.Args) {
+		return
+	}
+	model := c.chainModel(call)
+	if model == nil {
+		return
+	}
+
+	columns := c.updatedColumns(call.Args[idx])
+	if len(columns) == 0 {
+		c.reportIssuef(call.Args[idx].Pos(), model.Obj().Name(), "*", categoryORM,
+			"ORM update of %s via %s bypasses its methods", model.Obj().Name(), fn.Name())
+		return
+	}
+	for _, column := range columns {
+		if field := columnField(model, column.name); field != "" {
+			c.reportIssuef(column.pos, model.Obj().Name(), field, categoryORM,
+				"ORM update of %s.%s via %s bypasses its methods", model.Obj().Name(), field, fn.Name())
+			continue
+		}
+		c.reportIssuef(column.pos, model.Obj().Name(), column.name, categoryORM,
+			"ORM update of column %s of %s via %s bypasses its methods", column.name, model.Obj().Name(), fn.Name())
+	}
+}
+
+type updatedColumn struct {
+	name string
+	pos  token.Pos
+}
+
+// updatedColumns returns column or field names of a string constant, a map literal or a struct literal.
+// It returns nil when the columns cannot be determined statically.
+func (c *checker) updatedColumns(arg ast.Expr) []updatedColumn {
+	if name, ok := c.stringConstant(arg); ok {
+		return []updatedColumn{{name: columnName(name), pos: arg.Pos()}}
+	}
+
+	lit, ok := ast.Unparen(arg).(*ast.CompositeLit)
+	if !ok {
+		if u, isAddr := ast.Unparen(arg).(*ast.UnaryExpr); isAddr {
+			lit, ok = ast.Unparen(u.X).(*ast.CompositeLit)
+		}
+	}
+	if !ok {
+		return nil
+	}
+
+	var out []updatedColumn
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if name, ok := c.stringConstant(kv.Key); ok {
+			out = append(out, updatedColumn{name: columnName(name), pos: kv.Key.Pos()})
+		} else if id, ok := kv.Key.(*ast.Ident); ok {
+			out = append(out, updatedColumn{name: id.Name, pos: kv.Key.Pos()})
+		}
+	}
+	return out
+}
+
+// chainModel returns the protected struct passed to the nearest model method preceding the update call in its chain,
+// or nil if the model is not protected or not set. Arguments of other calls, like Where, are not models.
+func (c *checker) chainModel(update *ast.CallExpr) *types.Named {
+	call := update
+	for {
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+		if call, ok = ast.Unparen(sel.X).(*ast.CallExpr); !ok {
+			return nil
+		}
+		fn, ok := typeutil.Callee(c.TypesInfo, call).(*types.Func)
+		if !ok {
+			continue
+		}
+		if idx, ok := c.ormModels[fn.FullName()]; ok && idx < len(call.Args) {
+			return c.protectedStruct(deref(c.TypesInfo.TypeOf(call.Args[idx])))
+		}
+	}
+}
+
+// columnField maps a column name back to the struct field by the `gorm:"column:..."` tag,
+// the default GORM naming strategy (snake case) or the field name itself.
+func columnField(named *types.Named, column string) string {
+	s, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return ""
+	}
+	for i := range s.NumFields() {
+		f := s.Field(i)
+		if f.Name() == column || gormColumn(s.Tag(i)) == column {
+			return f.Name()
+		}
+	}
+	for i := range s.NumFields() {
+		if f := s.Field(i); gormColumn(s.Tag(i)) == "" && toSnakeCase(f.Name()) == column {
+			return f.Name()
+		}
+	}
+	return ""
+}
+
+// gormColumn returns the column name set by the gorm struct tag, or empty string.
+func gormColumn(tag string) string {
+	for _, part := range strings.Split(reflect.StructTag(tag).Get("gorm"), ";") {
+		if name, ok := strings.CutPrefix(strings.TrimSpace(part), "column:"); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// columnName extracts the column from expressions like "status", "users.status" or "status = ?".
+func columnName(expr string) string {
+	isNamePart := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+	}
+	expr = strings.TrimSpace(expr)
+	if i := strings.IndexFunc(expr, func(r rune) bool { return !isNamePart(r) }); i >= 0 {
+		expr = expr[:i]
+	}
+	if i := strings.LastIndex(expr, "."); i >= 0 {
+		expr = expr[i+1:]
+	}
+	return expr
+}
+
+// toSnakeCase converts a Go field name to snake case like GORM does: UserID -> user_id, APIKey -> api_key.
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// stringConstant returns the value of a constant string expression.
+func (c *checker) stringConstant(expr ast.Expr) (string, bool) {
+	tv, ok := c.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// protectedStruct returns the named struct type if it is protected, or nil.
+func (c *checker) protectedStruct(t types.Type) *types.Named {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+	if !c.isProtectedStruct(named) {
+		return nil
+	}
+	return named
+}
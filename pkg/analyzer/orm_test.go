@@ -0,0 +1,34 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestORMUpdates(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg:    []string{"User"},
+		ormAllowedArg: []string{"ormrepo"},
+		ormUpdatesArg: []string{"(*orm.Query).Set:0"},
+		ormModelsArg:  []string{"(*orm.Query).For:0"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "orm", "ormrepo")
+}
+
+func TestToSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"ID":        "id",
+		"UserID":    "user_id",
+		"APIKey":    "api_key",
+		"CreatedAt": "created_at",
+		"Address2":  "address2",
+		"HTTPProxy": "http_proxy",
+	} {
+		if got := toSnakeCase(name); got != want {
+			t.Errorf("toSnakeCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// registeredEntitiesFact is exported for packages registering entities, e.g. by db.AutoMigrate(&User{}).
+type registeredEntitiesFact struct {
+	Names []string // qualified type names, e.g. example.com/users.User
+}
+
+func (*registeredEntitiesFact) AFact() {}
+
+func (f *registeredEntitiesFact) String() string {
+	return "propro registered entities " + strings.Join(f.Names, ", ")
+}
+
+// discoverRegisteredEntities protects types passed to registration functions by type-checked calls in the analyzed
+// package, exported as a package fact for importers. Registrations anywhere in the module are found by a syntactic
+// scan when the analyzer is set up, so they apply even to packages analyzed before the registering one.
+func (c *checker) discoverRegisteredEntities() {
+	c.registered = map[string]bool{}
+	if len(c.RegistrationFuncs) == 0 {
+		return
+	}
+
+	if registered := c.registeredInPackage(); len(registered) > 0 {
+		c.ExportPackageFact(&registeredEntitiesFact{Names: registered})
+	}
+	for _, f := range c.AllPackageFacts() {
+		if fact, ok := f.Fact.(*registeredEntitiesFact); ok {
+			for _, qualified := range fact.Names {
+				c.registered[qualified] = true
+			}
+		}
+	}
+}
+
+// registeredInPackage returns qualified names of structs passed to registration functions in the analyzed package.
+func (c *checker) registeredInPackage() []string {
+	var out []string
+	for _, file := range c.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			fn, ok := typeutil.Callee(c.TypesInfo, call).(*types.Func)
+			if !ok || !slices.Contains(c.RegistrationFuncs, fn.FullName()) {
+				return true
+			}
+			for _, arg := range registrationArgs(call) {
+				named, ok := deref(c.TypesInfo.TypeOf(arg)).(*types.Named)
+				if !ok || named.Obj().Pkg() == nil {
+					continue
+				}
+				if _, ok := named.Underlying().(*types.Struct); ok {
+					out = append(out, qualifiedName(named))
+				}
+			}
+			return true
+		})
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// registrationArgs returns the registered values, expanding a spread composite literal like []any{&A{}, &B{}}....
+func registrationArgs(call *ast.CallExpr) []ast.Expr {
+	if call.Ellipsis == token.NoPos || len(call.Args) == 0 {
+		return call.Args
+	}
+	last := len(call.Args) - 1
+	if lit, ok := ast.Unparen(call.Args[last]).(*ast.CompositeLit); ok {
+		return append(slices.Clone(call.Args[:last]), lit.Elts...)
+	}
+	return call.Args[:last]
+}
+
+// registrationFunc is a parsed registration function, like example.com/registry.Register or
+// (*gorm.io/gorm.DB).AutoMigrate.
+type registrationFunc struct {
+	pkgPath string // package of the function or of the receiver type of the method
+	method  bool
+	name    string
+}
+
+func parseRegistrationFunc(spec string) (registrationFunc, bool) {
+	qualified, fn := strings.TrimSpace(spec), registrationFunc{}
+	if recv, name, ok := strings.Cut(qualified, ")."); ok && strings.HasPrefix(recv, "(") {
+		// (*gorm.io/gorm.DB).AutoMigrate: the package is that of the receiver type gorm.io/gorm.DB.
+		qualified, fn.method, fn.name = strings.TrimPrefix(recv[1:], "*"), true, name
+	}
+	i := strings.LastIndex(qualified, ".")
+	if i <= 0 {
+		return registrationFunc{}, false
+	}
+	fn.pkgPath = qualified[:i]
+	if !fn.method {
+		fn.name = qualified[i+1:]
+	}
+	return fn, true
+}
+
+// fileImports are the imports of a file: paths by their package names and dot-imported paths.
+type fileImports struct {
+	byName map[string]string
+	dot    map[string]bool
+}
+
+func importsOf(f *ast.File) fileImports {
+	imports := fileImports{byName: map[string]string{}, dot: map[string]bool{}}
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		switch name := importName(spec, path); name {
+		case "_":
+		case ".":
+			imports.dot[path] = true
+		default:
+			imports.byName[name] = path
+		}
+	}
+	return imports
+}
+
+// importName returns the name of the import, guessing the package name from the path if it is not renamed:
+// the last element without a major version, e.g. yaml for gopkg.in/yaml.v3 and gorm for gorm.io/gorm/v2.
+func importName(spec *ast.ImportSpec, path string) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elems[len(elems)-2]
+	}
+	name, _, _ = strings.Cut(name, ".")
+	return name
+}
+
+// scanModuleRegistrations parses all non-test Go files of the module and returns qualified names of types passed
+// to the registration functions. Function calls match by the package of their qualifier, calls of methods match
+// by name in packages declaring or importing the package of the receiver type, as types are not checked.
+func scanModuleRegistrations(root string, registrationFuncs []string) []string {
+	var funcs []registrationFunc
+	for _, spec := range registrationFuncs {
+		if fn, ok := parseRegistrationFunc(spec); ok {
+			funcs = append(funcs, fn)
+		}
+	}
+
+	var names []string
+	imported := map[string]map[string]bool{}        // package -> paths imported by its files
+	methodCalls := map[string]map[string][]string{} // package -> receiver package -> registered types
+	walkModuleFiles(root, func(pkgPath string, f *ast.File) {
+		imports := importsOf(f)
+		if imported[pkgPath] == nil {
+			imported[pkgPath], methodCalls[pkgPath] = map[string]bool{}, map[string][]string{}
+		}
+		for _, path := range imports.byName {
+			imported[pkgPath][path] = true
+		}
+		for path := range imports.dot {
+			imported[pkgPath][path] = true
+		}
+		inspectRegistrations(f, funcs, pkgPath, imports, func(fn registrationFunc, typeNames []string) {
+			if fn.method {
+				methodCalls[pkgPath][fn.pkgPath] = append(methodCalls[pkgPath][fn.pkgPath], typeNames...)
+			} else {
+				names = append(names, typeNames...)
+			}
+		})
+	})
+	for pkgPath, byRecv := range methodCalls {
+		for recvPkg, typeNames := range byRecv {
+			if pkgPath == recvPkg || imported[pkgPath][recvPkg] {
+				names = append(names, typeNames...)
+			}
+		}
+	}
+
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// inspectRegistrations calls yield with qualified names of types like &T{}, T{} or new(T) passed to calls of the file
+// matching one of the registration functions.
+func inspectRegistrations(f *ast.File, funcs []registrationFunc, pkgPath string, imports fileImports,
+	yield func(fn registrationFunc, typeNames []string),
+) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		for _, fn := range funcs {
+			if !registrationCallMatches(call, fn, pkgPath, imports) {
+				continue
+			}
+			var typeNames []string
+			for _, arg := range registrationArgs(call) {
+				if typeName := allocatedTypeName(arg, pkgPath, imports); typeName != "" {
+					typeNames = append(typeNames, typeName)
+				}
+			}
+			yield(fn, typeNames)
+		}
+		return true
+	})
+}
+
+// registrationCallMatches checks whether the call may call the registration function. Functions must be called
+// in their package, dot-imported or qualified by their package; methods must be called on a value, not a package.
+func registrationCallMatches(call *ast.CallExpr, fn registrationFunc, pkgPath string, imports fileImports) bool {
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		return !fn.method && fun.Name == fn.name && (pkgPath == fn.pkgPath || imports.dot[fn.pkgPath])
+	case *ast.SelectorExpr:
+		if fun.Sel.Name != fn.name {
+			return false
+		}
+		var path string
+		if x, ok := ast.Unparen(fun.X).(*ast.Ident); ok {
+			path = imports.byName[x.Name]
+		}
+		if fn.method {
+			return path == ""
+		}
+		return path == fn.pkgPath
+	}
+	return false
+}
+
+// allocatedTypeName returns the qualified type name of &T{}, T{} or new(T) in the package, or empty string.
+func allocatedTypeName(expr ast.Expr, pkgPath string, imports fileImports) string {
+	expr = ast.Unparen(expr)
+	if u, ok := expr.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		expr = ast.Unparen(u.X)
+	}
+	var typ ast.Expr
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		typ = e.Type
+	case *ast.CallExpr:
+		if id, ok := ast.Unparen(e.Fun).(*ast.Ident); ok && id.Name == "new" && len(e.Args) == 1 {
+			typ = e.Args[0]
+		}
+	}
+	switch t := typ.(type) {
+	case *ast.Ident:
+		return pkgPath + "." + t.Name
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok && imports.byName[x.Name] != "" {
+			return imports.byName[x.Name] + "." + t.Sel.Name
+		}
+	}
+	return ""
+}
+
+// walkModuleFiles parses all non-test Go files of the module, skipping testdata, vendor and hidden directories.
+// The visitor gets the import path of the package of the file, derived from the module path.
+func walkModuleFiles(root string, visit func(pkgPath string, f *ast.File)) {
+	modulePath := ""
+	if data, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+		modulePath = modfile.ModulePath(data)
+	}
+	fset := token.NewFileSet()
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			// Unreadable entries are skipped.
+		case d.IsDir():
+			if path != root && (d.Name() == "testdata" || d.Name() == "vendor" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+		case strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go"):
+			if f, perr := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution); perr == nil {
+				rel, _ := filepath.Rel(root, filepath.Dir(path))
+				visit(pathpkg.Join(modulePath, filepath.ToSlash(rel)), f)
+			}
+		}
+		return nil
+	})
+}
+
+// passModuleRoot returns the root of the module containing the analyzed package, or empty string.
+func passModuleRoot(pass *analysis.Pass) string {
+	if len(pass.Files) == 0 {
+		return ""
+	}
+	return moduleRoot(filepath.Dir(pass.Fset.File(pass.Files[0].Package).Name()))
+}
+
+// isDependencyModule checks whether the module root is in the module cache, like .../mod/example.com/lib@v1.2.0,
+// or is the standard library.
+func isDependencyModule(root string) bool {
+	return strings.Contains(filepath.Base(root), "@") || root == filepath.Join(build.Default.GOROOT, "src")
+}
+
+// moduleRoot returns the nearest directory containing go.mod, or empty string.
+func moduleRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
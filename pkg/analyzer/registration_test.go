@@ -0,0 +1,86 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRegisteredEntities(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		registrationArg: []string{"registration.Register", "(*gorm.io/gorm.DB).AutoMigrate"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "registration", "registrationuse")
+}
+
+func TestScanModuleRegistrations(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n",
+		"cmd/migrate/main.go": `package main
+
+import (
+	"example.com/app/orders"
+	"example.com/app/registry"
+	"example.com/app/users"
+	"gorm.io/gorm"
+)
+
+var db *gorm.DB
+
+func main() {
+	db.AutoMigrate(&users.User{}, orders.Order{}, new(orders.Line), &user)
+	registry.Register(&users.Admin{})
+}
+`,
+		"cmd/migrate/store.go": "package main\n\nfunc migrate() { db.AutoMigrate(&Migration{}) }\n",
+		"registry/registry.go": "package registry\n\nfunc Register(any) {}\n\nfunc init() { Register(Default{}) }\n",
+		"metrics/metrics.go": `package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+func init() {
+	prometheus.Register(&collector{})
+	cache.AutoMigrate(&Entry{})
+	Register(&Local{})
+}
+`,
+		"cmd/migrate/main_test.go":  "package main\n\nfunc init() { db.AutoMigrate(&Fixture{}) }\n",
+		"testdata/fixture.go":       "package fixture\n\nfunc init() { db.AutoMigrate(&Fixture{}) }\n",
+		"internal/store/invalid.go": "package store\n\nfunc {",
+	}
+	writeFiles(t, root, files)
+
+	got := scanModuleRegistrations(moduleRoot(filepath.Join(root, "cmd/migrate")),
+		[]string{"(*gorm.io/gorm.DB).AutoMigrate", "example.com/app/registry.Register"})
+	want := []string{
+		"example.com/app/cmd/migrate.Migration",
+		"example.com/app/orders.Line",
+		"example.com/app/orders.Order",
+		"example.com/app/registry.Default",
+		"example.com/app/users.Admin",
+		"example.com/app/users.User",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("scanModuleRegistrations() = %v, want %v", got, want)
+	}
+}
+
+func TestParseRegistrationFunc(t *testing.T) {
+	for spec, want := range map[string]registrationFunc{
+		"(*gorm.io/gorm.DB).AutoMigrate":    {pkgPath: "gorm.io/gorm", method: true, name: "AutoMigrate"},
+		"(example.com/app/registry.R).Add":  {pkgPath: "example.com/app/registry", method: true, name: "Add"},
+		"example.com/app/registry.Register": {pkgPath: "example.com/app/registry", name: "Register"},
+	} {
+		if got, ok := parseRegistrationFunc(spec); !ok || got != want {
+			t.Errorf("parseRegistrationFunc(%q) = %+v, %v, want %+v", spec, got, ok, want)
+		}
+	}
+	if _, ok := parseRegistrationFunc("Register"); ok {
+		t.Error("parseRegistrationFunc() accepted an unqualified function")
+	}
+}
@@ -0,0 +1,80 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+)
+
+// Result is the result of the analyzer for a package, for drivers which need more than diagnostics.
+type Result struct {
+	Protected []string // fully-qualified names of protected structs declared in the package
+	Issues    []Issue  // reported issues with their structured properties
+}
+
+// Issue is a reported violation. Its position and message are those of the reported diagnostic.
+type Issue struct {
+	Pos      token.Pos
+	Message  string
+	Category string // violation kind, e.g. protected or immutable
+	Struct   string // e.g. Order
+	Field    string // e.g. Status, empty if the violation does not concern a field
+	Func     string // enclosing function, e.g. Reset or Order.Pay, empty outside functions
+}
+
+// funcDeclName returns the name of the function, or of the method as Type.Method.
+func funcDeclName(fn *ast.FuncDecl) string {
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		if name := recvTypeName(fn.Recv.List[0].Type); name != "" {
+			return name + "." + fn.Name.Name
+		}
+	}
+	return fn.Name.Name
+}
+
+// recvTypeName returns the type name of a receiver like *Order or List[T].
+func recvTypeName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return recvTypeName(e.X)
+	case *ast.ParenExpr:
+		return recvTypeName(e.X)
+	case *ast.IndexExpr:
+		return recvTypeName(e.X)
+	case *ast.IndexListExpr:
+		return recvTypeName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+// Rule describes a violation kind, which is the category of its diagnostics.
+type Rule struct {
+	ID          string
+	Description string
+}
+
+// Rules returns the violation kinds reported by the analyzer, for report formats with rule metadata.
+// The returned slice is a copy which the caller may modify.
+func Rules() []Rule {
+	return slices.Clone(rules)
+}
+
+var rules = []Rule{
+	{categoryProtected, "Exported fields of protected structs are assigned only in their methods."},
+	{categoryWriters, "Fields are assigned only by their permitted writers."},
+	{categoryImmutable, "Immutable fields are assigned only in constructors."},
+	{categoryValueObject, "Value objects are not modified after construction."},
+	{categoryAggregate, "Members of aggregates are modified only through their aggregate root."},
+	{categoryCallers, "Methods are called only by their permitted callers."},
+	{categoryEvents, "Mutating methods of aggregate roots record domain events."},
+	{categoryInvariants, "Mutating methods call the invariant method after the mutation."},
+	{categoryHidden, "Hidden fields are read only in methods of their struct and are not logged."},
+	{categoryGuardedBy, "Guarded fields are written only while holding their mutex."},
+	{categoryDecode, "Protected structs are decoded only in allowed packages."},
+	{categoryORM, "Protected models are updated by column names only in allowed packages."},
+	{categoryConfig, "The configuration refers to existing structs, fields and functions."},
+	{categoryTests, "Production code does not import test builders."},
+	{categorySuppression, "Suppression directives have a reason and suppress an issue."},
+}
@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// dtoSuffixes are suffixes of names of structs excluded by the DTO heuristic.
+var dtoSuffixes = []string{"Request", "Response", "DTO", "Options"}
+
+// resolveScope compiles the protected and test package patterns and validates the exclude patterns. Patterns relative
+// to the module root like ./internal/domain/... are resolved to import paths using the module path.
+func (m *module) resolveScope(cfg *Config, root string) error {
+	modulePath := ""
+	if root != "" {
+		if data, err := os.ReadFile(filepath.Join(root, "go.mod")); err == nil {
+			modulePath = modfile.ModulePath(data)
+		}
+	}
+	var err error
+	if m.packages, err = compilePackagePatterns(protectedPkgsArg, cfg.ProtectedPackages, modulePath); err != nil {
+		return err
+	}
+	if m.testPkgs, err = compilePackagePatterns(testPackagesArg, cfg.TestPackages, modulePath); err != nil {
+		return err
+	}
+	for _, pattern := range cfg.ExcludeStructs {
+		if _, err := path.Match(strings.TrimSpace(pattern), ""); err != nil {
+			return fmt.Errorf("%w: %s: %q: %w", ErrInvalidConfigValue, excludeStructsArg, pattern, err)
+		}
+	}
+	return nil
+}
+
+// compilePackagePatterns compiles package patterns of the config key, resolving relative ones against the module path.
+func compilePackagePatterns(key string, patterns []string, modulePath string) ([]*regexp.Regexp, error) {
+	var out []*regexp.Regexp
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "." || strings.HasPrefix(pattern, "./") {
+			if modulePath == "" {
+				return nil, fmt.Errorf("%w: %s: relative package pattern outside of a module", ErrInvalidConfigValue, key)
+			}
+			pattern = path.Join(modulePath, pattern)
+		}
+		out = append(out, packagePatternRegexp(pattern))
+	}
+	return out, nil
+}
+
+// packagePatternRegexp converts a package pattern like example.com/app/internal/... to a regular expression.
+// As with the go command, a trailing /... also matches the package itself.
+func packagePatternRegexp(pattern string) *regexp.Regexp {
+	re := regexp.QuoteMeta(pattern)
+	if rest, ok := strings.CutSuffix(re, `/\.\.\.`); ok {
+		re = rest + `(/\.\.\.)?`
+	}
+	re = strings.ReplaceAll(re, `\.\.\.`, `.*`)
+	return regexp.MustCompile("^" + re + "$")
+}
+
+// inProtectedPackage checks whether the named type is declared in a package matching ProtectedPackages.
+func (c *checker) inProtectedPackage(named *types.Named) bool {
+	if named.Obj().Pkg() == nil {
+		return false
+	}
+	return slices.ContainsFunc(c.module.packages, func(re *regexp.Regexp) bool { return re.MatchString(named.Obj().Pkg().Path()) })
+}
+
+// excludedStruct checks whether the simple or fully-qualified name of the type matches one of ExcludeStructs.
+func (c *checker) excludedStruct(named *types.Named) bool {
+	for _, pattern := range c.ExcludeStructs {
+		pattern = strings.TrimSpace(pattern)
+		if ok, _ := path.Match(pattern, named.Obj().Name()); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, qualifiedName(named)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeDTO checks whether the struct is named like a DTO, e.g. CreateOrderRequest, or all its fields
+// carry json tags only, which suggests it is not persisted.
+func looksLikeDTO(named *types.Named) bool {
+	for _, suffix := range dtoSuffixes {
+		if strings.HasSuffix(named.Obj().Name(), suffix) {
+			return true
+		}
+	}
+	s, ok := named.Underlying().(*types.Struct)
+	if !ok || s.NumFields() == 0 {
+		return false
+	}
+	for i := range s.NumFields() {
+		keys := tagKeys(s.Tag(i))
+		if len(keys) == 0 || slices.ContainsFunc(keys, func(key string) bool { return key != "json" }) {
+			return false
+		}
+	}
+	return true
+}
+
+// tagKeys returns keys of a struct tag in the conventional format, e.g. json and gorm of `json:"id" gorm:"primaryKey"`.
+func tagKeys(tag string) []string {
+	var out []string
+	for {
+		tag = strings.TrimLeft(tag, " ")
+		i := strings.Index(tag, `:"`)
+		if i <= 0 {
+			return out
+		}
+		out = append(out, tag[:i])
+		tag = tag[i+2:]
+		j := 0
+		for j < len(tag) && tag[j] != '"' {
+			if tag[j] == '\\' {
+				j++
+			}
+			j++
+		}
+		if j >= len(tag) {
+			return out
+		}
+		tag = tag[j+1:]
+	}
+}
@@ -0,0 +1,73 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestProtectedPackages(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		protectedPkgsArg:  []any{"scope/domain/..."},
+		excludeStructsArg: []any{"*Fixture"},
+		dtoHeuristicArg:   true,
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "scope/app")
+}
+
+func TestPackagePatternRegexp(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"example.com/app/internal/domain/...", "example.com/app/internal/domain", true},
+		{"example.com/app/internal/domain/...", "example.com/app/internal/domain/orders", true},
+		{"example.com/app/internal/domain/...", "example.com/app/internal/domainx", false},
+		{"example.com/app/.../domain", "example.com/app/billing/domain", true},
+		{"example.com/app/internal/domain", "example.com/app/internal/domain/orders", false},
+	} {
+		if got := packagePatternRegexp(tc.pattern).MatchString(tc.path); got != tc.want {
+			t.Errorf("%q matches %q = %v, want %v", tc.pattern, tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestTagKeys(t *testing.T) {
+	got := tagKeys(`json:"id,omitempty" gorm:"column:id;comment:\"ID\""  db:"id"`)
+	if want := []string{"json", "gorm", "db"}; !slices.Equal(got, want) {
+		t.Errorf("tagKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestListProtectedStructs(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":                         "module example.com/app\n\ngo 1.24\n",
+		"internal/domain/order.go":       "package domain\n\ntype Order struct{ Status string }\n\ntype OrderFixture struct{ Status string }\n",
+		"internal/domain/dto.go":         "package domain\n\ntype CreateOrderRequest struct{ Status string }\n",
+		"internal/domain/items/items.go": "package items\n\ntype Item struct{ Name string }\n",
+		"api/api.go":                     "package api\n\ntype Order struct{ Status string }\n",
+	})
+	cfg := map[string]any{
+		protectedPkgsArg:  "./internal/domain/...",
+		excludeStructsArg: "*Fixture",
+		dtoHeuristicArg:   "true",
+	}
+
+	got, err := ListProtectedStructs(cfg, root, "./...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"example.com/app/internal/domain.Order", "example.com/app/internal/domain/items.Item"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ListProtectedStructs() = %v, want %v", got, want)
+	}
+
+	if _, err := ListProtectedStructs(cfg, filepath.Join(root, "missing"), "./..."); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
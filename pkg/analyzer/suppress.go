@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	// ignoreDirective suppresses issues on its line or in the statement it precedes: //propro:ignore <reason>.
+	ignoreDirective = "ignore"
+	// ignoreFileDirective suppresses all issues in its file: //propro:ignore-file <reason>.
+	ignoreFileDirective = "ignore-file"
+)
+
+// suppression is a //propro:ignore or //propro:ignore-file directive.
+type suppression struct {
+	directive  *ast.Comment
+	name       string
+	file       string
+	start, end int // suppressed lines, both inclusive; ignored for ignore-file
+	used       bool
+}
+
+// findSuppressions collects suppression directives of the package. Directives without a reason are reported
+// and ignored, unless AllowIgnoreWithoutReason is set.
+func (c *checker) findSuppressions() {
+	for _, file := range c.Files {
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				name, reason, _ := strings.Cut(strings.TrimPrefix(comment.Text, directivePrefix), " ")
+				if !strings.HasPrefix(comment.Text, directivePrefix) || (name != ignoreDirective && name != ignoreFileDirective) {
+					continue
+				}
+				if strings.TrimSpace(reason) == "" && !c.AllowIgnoreWithoutReason {
+					c.Report(analysis.Diagnostic{
+						Pos:      comment.Pos(),
+						Category: categorySuppression,
+						Message:  directivePrefix + name + " directive requires a reason",
+					})
+					continue
+				}
+				s := &suppression{directive: comment, name: name, file: c.Fset.File(comment.Pos()).Name()}
+				if name == ignoreDirective {
+					s.start, s.end = c.suppressedLines(file, comment)
+				}
+				c.suppressions = append(c.suppressions, s)
+			}
+		}
+	}
+}
+
+// suppressedLines returns the lines suppressed by the //propro:ignore comment: its line if it follows code,
+// or the next line otherwise, extended to the end of statements and declarations starting on that line.
+func (c *checker) suppressedLines(file *ast.File, comment *ast.Comment) (start, end int) {
+	line := c.Fset.Position(comment.Pos()).Line
+	start = line + 1
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n != nil && n.Pos() < comment.Pos() && c.Fset.Position(n.Pos()).Line == line {
+			start = line
+		}
+		return start != line
+	})
+
+	end = start
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n.(type) {
+		case ast.Stmt, ast.Decl, ast.Spec:
+			if c.Fset.Position(n.Pos()).Line == start {
+				end = max(end, c.Fset.Position(n.End()).Line)
+			}
+		}
+		return true
+	})
+	return start, end
+}
+
+// suppressed checks whether an issue at the position is suppressed, marking the matching directives as used.
+func (c *checker) suppressed(pos token.Pos) bool {
+	position := c.Fset.Position(pos)
+	found := false
+	for _, s := range c.suppressions {
+		if s.file == position.Filename && (s.name == ignoreFileDirective || s.start <= position.Line && position.Line <= s.end) {
+			s.used = true
+			found = true
+		}
+	}
+	return found
+}
+
+// reportUnusedSuppressions reports directives which suppressed no issue, so they do not outlive the issues.
+func (c *checker) reportUnusedSuppressions() {
+	for _, s := range c.suppressions {
+		if !s.used {
+			c.Report(analysis.Diagnostic{
+				Pos:      s.directive.Pos(),
+				Category: categorySuppression,
+				Message:  "unused " + directivePrefix + s.name + " directive",
+			})
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestSuppressions(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		// The warning about Missing is reported at the package clause of legacy.go, which is ignored as a whole.
+		structsArg: []string{"Order", "Draft", "Missing"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "suppress")
+}
+
+func TestSuppressionRequiresReason(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg: []string{"Order"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "suppressnoreason")
+}
+
+func TestSuppressionWithoutReasonAllowed(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg:        []string{"Order"},
+		ignoreNoReasonArg: true,
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "suppressnoreasonallowed")
+}
@@ -0,0 +1,137 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Policies for test code, see the tests config.
+const (
+	testsEnforce      = "enforce"
+	testsIgnore       = "ignore"
+	testsBuildersOnly = "builders-only"
+)
+
+// defaultTestBuilders are patterns of test builder functions and methods used if none is configured.
+var defaultTestBuilders = []string{"*Builder.*", "given*"}
+
+// validateTestsPolicy checks the tests policy and the test builder patterns.
+func validateTestsPolicy(cfg *Config) error {
+	if cfg.Tests != "" && !slices.Contains([]string{testsEnforce, testsIgnore, testsBuildersOnly}, cfg.Tests) {
+		return fmt.Errorf("%w: %s: %q, expected %s, %s or %s",
+			ErrInvalidConfigValue, testsArg, cfg.Tests, testsEnforce, testsIgnore, testsBuildersOnly)
+	}
+	for _, pattern := range cfg.TestBuilders {
+		if _, err := path.Match(strings.TrimSpace(pattern), ""); err != nil {
+			return fmt.Errorf("%w: %s: %q: %w", ErrInvalidConfigValue, testBuildersArg, pattern, err)
+		}
+	}
+	return nil
+}
+
+// exemptByTestsPolicy checks whether an issue at the position is not reported due to the tests policy.
+// With ignore, no issue in test code is reported; with builders-only, issues in test builders are not reported.
+func (c *checker) exemptByTestsPolicy(pos token.Pos) bool {
+	if c.Tests != testsIgnore && c.Tests != testsBuildersOnly || !c.isTestCode(pos) {
+		return false
+	}
+	if c.Tests == testsIgnore {
+		return true
+	}
+	fn := c.findEnclosingFunc(pos)
+	if fn == nil {
+		return false
+	}
+	obj, ok := c.TypesInfo.Defs[fn.Name].(*types.Func)
+	return ok && c.isTestBuilder(obj)
+}
+
+// isTestCode checks whether the position is in a _test.go file or in a test helper package.
+func (c *checker) isTestCode(pos token.Pos) bool {
+	return strings.HasSuffix(c.Fset.File(pos).Name(), "_test.go") || c.isTestHelperPackage(c.Pkg)
+}
+
+// isTestHelperPackage checks whether the package matches the configured test packages. Without them, test helper
+// packages are those importing testing, whatever their name, so production packages like latest are not test code
+// while helpers like fixtures are. Imports of _test.go files of the analyzed package do not count.
+func (c *checker) isTestHelperPackage(pkg *types.Package) bool {
+	if len(c.module.testPkgs) > 0 {
+		return slices.ContainsFunc(c.module.testPkgs, func(re *regexp.Regexp) bool { return re.MatchString(pkg.Path()) })
+	}
+	if pkg != c.Pkg {
+		return slices.ContainsFunc(pkg.Imports(), func(imp *types.Package) bool { return imp.Path() == "testing" })
+	}
+	return slices.ContainsFunc(c.Files, func(file *ast.File) bool {
+		return !strings.HasSuffix(c.Fset.File(file.Package).Name(), "_test.go") &&
+			slices.ContainsFunc(file.Imports, func(spec *ast.ImportSpec) bool { return spec.Path.Value == `"testing"` })
+	})
+}
+
+// isTestBuilder checks whether the function, or the method as Type.Method, matches one of the test builder patterns.
+func (c *checker) isTestBuilder(fn *types.Func) bool {
+	name := fn.Name()
+	if recv := fn.Signature().Recv(); recv != nil {
+		if named, ok := deref(recv.Type()).(*types.Named); ok {
+			name = named.Obj().Name() + "." + name
+		}
+	}
+	patterns := c.TestBuilders
+	if len(patterns) == 0 {
+		patterns = defaultTestBuilders
+	}
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		ok, _ := path.Match(strings.TrimSpace(pattern), name)
+		return ok
+	})
+}
+
+// checkTestBuilderImports reports production code importing test helper packages which declare test builders,
+// as with builders-only, the builders may write protected fields.
+func (c *checker) checkTestBuilderImports() {
+	if c.Tests != testsBuildersOnly || c.isTestHelperPackage(c.Pkg) {
+		return
+	}
+	for _, file := range c.Files {
+		if strings.HasSuffix(c.Fset.File(file.Package).Name(), "_test.go") {
+			continue
+		}
+		for _, spec := range file.Imports {
+			pkgName := c.TypesInfo.PkgNameOf(spec)
+			if pkgName == nil || !c.isTestHelperPackage(pkgName.Imported()) || !c.declaresTestBuilders(pkgName.Imported()) {
+				continue
+			}
+			c.reportIssuef(spec.Pos(), "", "", categoryTests,
+				"production code imports test builders of package %s", pkgName.Imported().Path())
+		}
+	}
+}
+
+// declaresTestBuilders checks whether the package declares functions or methods matching the test builder patterns.
+func (c *checker) declaresTestBuilders(pkg *types.Package) bool {
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		switch obj := scope.Lookup(name).(type) {
+		case *types.Func:
+			if c.isTestBuilder(obj) {
+				return true
+			}
+		case *types.TypeName:
+			named, ok := obj.Type().(*types.Named)
+			if !ok {
+				continue
+			}
+			for m := range named.Methods() {
+				if c.isTestBuilder(m) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package analyzer
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestTestsBuildersOnly(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg: []string{"Order"},
+		testsArg:   testsBuildersOnly,
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "testpolicy", "testpolicy/ordertest", "testpolicy/app")
+}
+
+func TestTestsIgnore(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg: []string{"Order"},
+		testsArg:   testsIgnore,
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "testignore", "testignore/ordertest", "testignore/latest", "testignore/manifest", "testignore/fakes")
+}
+
+func TestTestsIgnoreWithTestPackages(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg:      []string{"Order"},
+		testsArg:        testsIgnore,
+		testPackagesArg: []string{"testignore/fixtures/..."},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "testignore/fixtures", "testignore/latest")
+}
+
+func TestValidateTestsPolicy(t *testing.T) {
+	for name, cfg := range map[string]*Config{
+		"unknown policy":      {Tests: "skip"},
+		"bad builder pattern": {Tests: testsBuildersOnly, TestBuilders: []string{"[Builder"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := validateTestsPolicy(cfg); !errors.Is(err, ErrInvalidConfigValue) {
+				t.Errorf("validateTestsPolicy() error = %v, want %v", err, ErrInvalidConfigValue)
+			}
+		})
+	}
+}
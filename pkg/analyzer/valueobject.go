@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+)
+
+// valueObjectDirective marks a fully immutable type: //propro:value-object.
+const valueObjectDirective = "value-object"
+
+// checkValueObjectField reports writes to fields of value objects made outside their constructors.
+// It returns true when the field belongs to a value object, i.e. the write has been fully handled here.
+func (c *checker) checkValueObjectField(sel *ast.SelectorExpr) bool {
+	owner, field := c.selectedField(sel)
+	if field == nil || !c.isValueObject(owner) {
+		return false
+	}
+
+	if !c.isValueObjectConstruction(c.findEnclosingFunc(sel.Pos()), owner, sel.X) {
+		c.reportIssuef(sel.Pos(), owner.Obj().Name(), field.Name(), categoryValueObject,
+			"assignment to field %s.%s of value object is forbidden outside constructors returning %s",
+			owner.Obj().Name(), field.Name(), owner.Obj().Name())
+	}
+	return true
+}
+
+// checkValueObjectOverwrite reports overwrites like *v = other of value objects made outside their constructors.
+func (c *checker) checkValueObjectOverwrite(lhs ast.Expr) {
+	star, ok := ast.Unparen(lhs).(*ast.StarExpr)
+	if !ok {
+		return
+	}
+	named, ok := deref(c.TypesInfo.TypeOf(star)).(*types.Named)
+	if !ok || !c.isValueObject(named) {
+		return
+	}
+	if !c.isValueObjectConstruction(c.findEnclosingFunc(lhs.Pos()), named, star.X) {
+		c.reportIssuef(lhs.Pos(), named.Obj().Name(), "*", categoryValueObject,
+			"assignment to value object %s is forbidden outside constructors returning %s", named.Obj().Name(), named.Obj().Name())
+	}
+}
+
+// checkValueObjectReceivers warns about pointer receiver methods declared on value objects of the analyzed package.
+func (c *checker) checkValueObjectReceivers() {
+	for _, file := range c.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+			recv := fn.Recv.List[0]
+			ptr, ok := c.TypesInfo.TypeOf(recv.Type).(*types.Pointer)
+			if !ok {
+				continue
+			}
+			named, ok := ptr.Elem().(*types.Named)
+			if !ok || !c.isValueObject(named) {
+				continue
+			}
+			c.reportIssuef(recv.Pos(), named.Obj().Name(), fn.Name.Name, categoryValueObject,
+				"value object %s should not have pointer receiver method %s; return a new value instead",
+				named.Obj().Name(), fn.Name.Name)
+		}
+	}
+}
+
+// isValueObject checks the named type against the config and the //propro:value-object directive.
+func (c *checker) isValueObject(named *types.Named) bool {
+	if slices.Contains(c.ValueObjects, named.Obj().Name()) {
+		return true
+	}
+	_, ok := c.objectDirectives(named.Obj())[valueObjectDirective]
+	return ok
+}
+
+// isValueObjectConstruction checks whether a write to target in fn constructs the value object: fn is its constructor
+// and target is rooted in a value created by fn, i.e. a local variable, a named result or the value receiver.
+// Parameters are not writable, as they may point to values of the caller.
+func (c *checker) isValueObjectConstruction(fn *ast.FuncDecl, named *types.Named, target ast.Expr) bool {
+	root := rootIdent(target)
+	if root == nil || !c.isValueObjectConstructor(fn, named) {
+		return false
+	}
+	v, ok := c.TypesInfo.Uses[root].(*types.Var)
+	if !ok {
+		return false
+	}
+	if fn.Recv != nil && len(fn.Recv.List[0].Names) > 0 && c.TypesInfo.Defs[fn.Recv.List[0].Names[0]] == v {
+		return true
+	}
+	inBody := fn.Body != nil && v.Pos() > fn.Body.Pos() && v.Pos() < fn.Body.End()
+	results := fn.Type.Results
+	return inBody || results != nil && v.Pos() > results.Pos() && v.Pos() < results.End()
+}
+
+// isValueObjectConstructor checks whether fn is a function or value receiver method declared in the package
+// of the value object and returning it.
+func (c *checker) isValueObjectConstructor(fn *ast.FuncDecl, named *types.Named) bool {
+	if fn == nil {
+		return false
+	}
+	obj, ok := c.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok || obj.Pkg() != named.Obj().Pkg() {
+		return false
+	}
+	sig, ok := obj.Type().(*types.Signature)
+	if !ok {
+		return false
+	}
+	if recv := sig.Recv(); recv != nil {
+		if _, isPtr := recv.Type().(*types.Pointer); isPtr {
+			return false
+		}
+	}
+	for i := range sig.Results().Len() {
+		if types.Identical(deref(sig.Results().At(i).Type()), named) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package analyzer
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestValueObjects(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		valueObjectsArg: []string{"Money"},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "valueobject", "valueobjectuse")
+}
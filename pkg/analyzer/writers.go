@@ -0,0 +1,114 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// writersDirective restricts writers of a field: //propro:writers Submit, Approve, NewOrder.
+const writersDirective = "writers"
+
+// checkFieldWriters reports writes to fields with a writer ACL made outside the permitted writers.
+// It returns true when the field has an ACL, i.e. the write has been fully handled here.
+func (c *checker) checkFieldWriters(sel *ast.SelectorExpr) bool {
+	owner, field := c.selectedField(sel)
+	if field == nil {
+		return false
+	}
+
+	writers := c.permittedWriters(owner, field)
+	if len(writers) == 0 {
+		return false
+	}
+
+	fn := c.findEnclosingFunc(sel.Pos())
+	for _, writer := range writers {
+		if c.funcMatches(fn, writer, owner) {
+			return true
+		}
+	}
+
+	c.reportIssuef(sel.Pos(), owner.Obj().Name(), field.Name(), categoryWriters,
+		"assignment to field %s.%s is forbidden outside its permitted writers: %s",
+		owner.Obj().Name(), field.Name(), strings.Join(writers, ", "))
+	return true
+}
+
+// permittedWriters returns the writer ACL of the field from config or from the //propro:writers directive.
+func (c *checker) permittedWriters(owner *types.Named, field *types.Var) []string {
+	if writers, ok := c.FieldWriters[owner.Obj().Name()+"."+field.Name()]; ok {
+		return writers
+	}
+	return c.objectDirectives(field)[writersDirective]
+}
+
+// selectedField returns the field selected by sel together with the named struct declaring it.
+// Promoted fields are attributed to the embedded struct that declares them.
+func (c *checker) selectedField(sel *ast.SelectorExpr) (*types.Named, *types.Var) {
+	selection, ok := c.TypesInfo.Selections[sel]
+	if !ok || selection.Kind() != types.FieldVal {
+		return nil, nil
+	}
+	field, ok := selection.Obj().(*types.Var)
+	if !ok {
+		return nil, nil
+	}
+
+	var owner *types.Named
+	t := selection.Recv()
+	for _, idx := range selection.Index() {
+		named, ok := deref(t).(*types.Named)
+		if !ok {
+			return nil, nil
+		}
+		s, ok := named.Underlying().(*types.Struct)
+		if !ok {
+			return nil, nil
+		}
+		owner = named
+		t = s.Field(idx).Type()
+	}
+	if owner == nil {
+		return nil, nil
+	}
+	return owner, field
+}
+
+// funcMatches checks whether fn is the function or method described by spec. Spec is either "Name" (a method
+// of the owner struct or a plain function) or "Type.Name" (a method of Type), both declared in the package of the owner,
+// or the full name of a function or method of any package, e.g. example.com/app/orders.Submit or (*example.com/app.Order).Pay.
+func (c *checker) funcMatches(fn *ast.FuncDecl, spec string, owner *types.Named) bool {
+	if fn == nil {
+		return false
+	}
+	obj, ok := c.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	spec = strings.TrimSpace(spec)
+	if obj.FullName() == spec {
+		return true
+	}
+	if obj.Pkg() != owner.Obj().Pkg() {
+		return false
+	}
+
+	spec = strings.NewReplacer("(", "", ")", "", "*", "").Replace(spec)
+	recvName, funcName, qualified := strings.Cut(spec, ".")
+	if !qualified {
+		funcName, recvName = recvName, owner.Obj().Name()
+		if fn.Recv == nil {
+			return fn.Name.Name == funcName
+		}
+	}
+	if fn.Name.Name != funcName || fn.Recv == nil {
+		return false
+	}
+	for _, recv := range fn.Recv.List {
+		if isSameStructType(c.TypesInfo.TypeOf(recv.Type), recvName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package analyzer
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestFieldWriters(t *testing.T) {
+	testdata := testdataDir()
+	cfg := map[string]any{
+		structsArg: []string{"Order"},
+		fieldWritersArg: map[string]any{
+			"Invoice.Total": []any{"Order.Recalculate", "Reset", "writersuse.Settle"},
+		},
+	}
+
+	analysistest.Run(t, testdata, NewAnalyzer(cfg), "writers", "writersuse")
+}
+
+func TestParseStringListMapFromCLI(t *testing.T) {
+	got, err := parseStringListMap(" Order.Status = Submit, Cancel ; Order.Total=AddLine;; ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("unexpected map: %v", got)
+	}
+	if s := got["Order.Status"]; len(s) != 2 || s[0] != "Submit" || s[1] != "Cancel" {
+		t.Errorf("unexpected Order.Status writers: %v", s)
+	}
+	if s := got["Order.Total"]; len(s) != 1 || s[0] != "AddLine" {
+		t.Errorf("unexpected Order.Total writers: %v", s)
+	}
+
+	for _, value := range []string{"Order.Status=Submit;broken", "=Submit"} {
+		if _, err := parseStringListMap(value); !errors.Is(err, ErrInvalidConfigValue) {
+			t.Errorf("parseStringListMap(%q) error = %v, want %v", value, err, ErrInvalidConfigValue)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+)
+
+const baselineVersion = 1
+
+var ErrBaseline = errors.New("baseline cannot be used")
+
+// baseline is the file of existing issues which are not reported. Besides the fingerprints, it stores
+// the properties of the issues, so the file is reviewable.
+type baseline struct {
+	Version int             `json:"version"`
+	Issues  []baselineIssue `json:"issues"`
+}
+
+type baselineIssue struct {
+	Fingerprint string `json:"fingerprint"`
+	Category    string `json:"category,omitempty"`
+	Package     string `json:"package,omitempty"`
+	Struct      string `json:"struct,omitempty"`
+	Field       string `json:"field,omitempty"`
+	Func        string `json:"function,omitempty"`
+	Statement   string `json:"statement,omitempty"`
+}
+
+func writeBaseline(path string, issues []*issue) error {
+	b := baseline{Version: baselineVersion, Issues: make([]baselineIssue, 0, len(issues))}
+	for _, is := range issues {
+		b.Issues = append(b.Issues, baselineIssue{
+			Fingerprint: is.Fingerprint,
+			Category:    is.Category,
+			Package:     is.Package,
+			Struct:      is.Struct,
+			Field:       is.Field,
+			Func:        is.Func,
+			Statement:   is.Statement,
+		})
+	}
+	// Sorted independently of positions, so the file changes only with the issues.
+	slices.SortFunc(b.Issues, func(x, y baselineIssue) int {
+		return cmp.Or(
+			cmp.Compare(x.Package, y.Package),
+			cmp.Compare(x.Func, y.Func),
+			cmp.Compare(x.Struct, y.Struct),
+			cmp.Compare(x.Field, y.Field),
+			cmp.Compare(x.Fingerprint, y.Fingerprint),
+		)
+	})
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBaseline, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("%w: %w", ErrBaseline, err)
+	}
+	return nil
+}
+
+func readBaseline(path string) (*baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBaseline, err)
+	}
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrBaseline, path, err)
+	}
+	if b.Version != baselineVersion {
+		return nil, fmt.Errorf("%w: %s: unsupported version %d", ErrBaseline, path, b.Version)
+	}
+	return &b, nil
+}
+
+// filter returns the issues which are not in the baseline and the number of baseline issues which are fixed.
+func (b *baseline) filter(issues []*issue) ([]*issue, int) {
+	known := map[string]bool{}
+	for _, is := range b.Issues {
+		known[is.Fingerprint] = true
+	}
+	var out []*issue
+	found := map[string]bool{}
+	for _, is := range issues {
+		if known[is.Fingerprint] {
+			found[is.Fingerprint] = true
+			continue
+		}
+		out = append(out, is)
+	}
+	return out, len(known) - len(found)
+}
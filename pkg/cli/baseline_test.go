@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBaseline(t *testing.T) {
+	root := newModule(t)
+
+	code, _, stderr := run(t, root, "-baseline-write=propro-baseline.json", "./...")
+	if code != exitOK {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "propro-baseline.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Issues) != 2 {
+		t.Fatalf("expected 2 baseline issues, got:\n%s", data)
+	}
+	if got := b.Issues[0]; got.Struct != "Order" || got.Field != "Status" || got.Func != "Reset" ||
+		got.Statement != `o.Status = ""` || got.Package != "example.com/app/order" {
+		t.Errorf("unexpected baseline issue %+v", got)
+	}
+
+	code, _, stderr = run(t, root, "-baseline=propro-baseline.json", "./...")
+	if code != exitOK || stderr != "" {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+
+	// Added lines move the existing issues, one of them is fixed and a new one is added.
+	writeFiles(t, root, map[string]string{"order/order.go": `package order
+
+// Order is an order.
+type Order struct {
+	Status string
+	Total  int
+}
+
+func Reset(o *Order) {
+
+	o.Status = ""
+	o.Status = ""
+}
+`})
+	code, _, stderr = run(t, root, "-baseline=propro-baseline.json", "./...")
+	if code != exitIssues {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], "1 baseline issues are fixed, update the baseline with -baseline-write") ||
+		!strings.Contains(lines[1], "order.go:12:2: assignment to exported field Order.Status") {
+		t.Errorf("unexpected output:\n%s", stderr)
+	}
+}
+
+func TestBaselineInvalid(t *testing.T) {
+	root := newModule(t)
+	writeFiles(t, root, map[string]string{"propro-baseline.json": `{"version": 2, "issues": []}`})
+
+	if code, _, stderr := run(t, root, "-baseline=propro-baseline.json"); code != exitError ||
+		!strings.Contains(stderr, "unsupported version 2") {
+		t.Errorf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	if code, _, stderr := run(t, root, "-baseline=missing.json"); code != exitError ||
+		!strings.Contains(stderr, ErrBaseline.Error()) {
+		t.Errorf("exit code %d, stderr:\n%s", code, stderr)
+	}
+}
@@ -0,0 +1,333 @@
+// Package cli implements the standalone propro command. Unlike singlechecker, it knows the structured issues of the
+// analyzer, so it can compare them with a baseline of existing violations or report only those on changed lines.
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/digitalstraw/propro/v2/pkg/analyzer"
+	"golang.org/x/tools/go/analysis"
+	gochecker "golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/packages"
+)
+
+// Exit codes of Run, the same as of singlechecker.
+const (
+	exitOK     = 0
+	exitError  = 1
+	exitIssues = 3
+)
+
+var (
+	ErrLoad    = errors.New("packages cannot be loaded")
+	ErrOptions = errors.New("invalid options")
+)
+
+type options struct {
+	tests          bool
+	baseline       string
+	baselineWrite  string
+	newFromRev     string
+	newFromPatch   string
+	wholeFunctions bool
+	format         string
+	output         string
+	exitZero       bool
+	maxIssues      int
+
+	json       bool
+	context    int
+	cpuProfile string
+	memProfile string
+	trace      string
+}
+
+// Run runs propro in the directory with the command line arguments and returns the exit code:
+// 0 if there are no issues, 1 on errors and 3 if issues are reported, unless -exit-zero or -max-issues allow them.
+// Invoked by go vet -vettool, Run reads os.Args and exits itself.
+func Run(dir string, args []string, stdout, stderr io.Writer) int {
+	settings, err := analyzer.FindConfig(dir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	// go vet -vettool=propro runs it like singlechecker did, which hands the unit config file to unitchecker.
+	if isVetTool(args) {
+		singlechecker.Main(analyzer.NewAnalyzer(settings))
+	}
+
+	// propro list [packages] prints the effective protected structs.
+	if len(args) > 0 && args[0] == "list" {
+		return list(dir, args[1:], stdout, stderr)
+	}
+
+	// The analyzer of the working directory defines the flags, packages are analyzed by analyzers of their directories.
+	a := analyzer.NewAnalyzer(settings)
+	fs := flag.NewFlagSet(a.Name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	a.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	opts := &options{}
+	fs.BoolVar(&opts.tests, "test", true, "indicates whether test files should be analyzed, too")
+	fs.StringVar(&opts.baseline, "baseline", "", "report only issues which are not in the baseline file")
+	fs.StringVar(&opts.baselineWrite, "baseline-write", "", "write all issues to the baseline file instead of reporting them")
+	fs.StringVar(&opts.newFromRev, "new-from-rev", "", "report only issues on lines changed since the git revision")
+	fs.StringVar(&opts.newFromPatch, "new-from-patch", "", "report only issues on lines changed by the unified diff file")
+	fs.BoolVar(&opts.wholeFunctions, "new-whole-functions", false,
+		"with -new-from-rev or -new-from-patch, report all issues in changed functions")
+	fs.StringVar(&opts.format, "format", "text", "report format: "+formatNames())
+	fs.StringVar(&opts.output, "output", "", "write the report to the file instead of the standard output")
+	fs.BoolVar(&opts.exitZero, "exit-zero", false, "exit with 0 even if issues are reported")
+	fs.IntVar(&opts.maxIssues, "max-issues", 0, "exit with 0 if there are at most this number of issues")
+	fs.BoolVar(&opts.json, "json", false, "emit JSON output like singlechecker and exit with 0 even if issues are reported")
+	fs.IntVar(&opts.context, "c", -1, "display offending line with this many lines of context")
+	fs.StringVar(&opts.cpuProfile, "cpuprofile", "", "write CPU profile to this file")
+	fs.StringVar(&opts.memProfile, "memprofile", "", "write memory profile to this file")
+	fs.StringVar(&opts.trace, "trace", "", "write trace log to this file")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitError
+	}
+	if err := opts.validate(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	stopProfiling, err := opts.startProfiling(dir)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	issues, err := analyze(withFlags(fs), dir, opts.tests, patterns(fs.Args()))
+	if perr := stopProfiling(); err == nil {
+		err = perr
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	if opts.baselineWrite != "" {
+		if err := writeBaseline(absPath(dir, opts.baselineWrite), issues); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitError
+		}
+		fmt.Fprintf(stderr, "%d issues written to the baseline %s\n", len(issues), opts.baselineWrite)
+		return exitOK
+	}
+	if issues, err = opts.filter(dir, issues, stderr); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+
+	if err := opts.report(dir, issues, stdout, stderr); err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	if opts.exitZero || opts.json || len(issues) <= opts.maxIssues {
+		return exitOK
+	}
+	return exitIssues
+}
+
+func (o *options) validate() error {
+	if o.newFromRev != "" && o.newFromPatch != "" {
+		return fmt.Errorf("%w: -new-from-rev and -new-from-patch cannot be used together", ErrOptions)
+	}
+	if _, ok := formatters[o.format]; !ok {
+		return fmt.Errorf("%w: unknown format %q, use one of %s", ErrOptions, o.format, formatNames())
+	}
+	if o.json && o.format != "text" {
+		return fmt.Errorf("%w: -json and -format cannot be used together", ErrOptions)
+	}
+	if o.maxIssues < 0 {
+		return fmt.Errorf("%w: -max-issues must not be negative", ErrOptions)
+	}
+	return nil
+}
+
+// report writes the issues in the format to the output file. Without the output file, text is written
+// to the standard error like by singlechecker, other formats to the standard output.
+func (o *options) report(dir string, issues []*issue, stdout, stderr io.Writer) (err error) {
+	format := formatters[o.format]
+	switch {
+	case o.json:
+		format = writeVetJSON
+	case o.context >= 0:
+		format = textWithContext(o.context)
+	}
+	w := stdout
+	if o.format == "text" && !o.json {
+		w = stderr
+	}
+	if o.output != "" {
+		f, err := os.Create(absPath(dir, o.output))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFormat, err)
+		}
+		defer func() {
+			if cerr := f.Close(); cerr != nil && err == nil {
+				err = fmt.Errorf("%w: %w", ErrFormat, cerr)
+			}
+		}()
+		w = f
+	}
+	return format(w, dir, issues)
+}
+
+// filter returns the issues which are not in the baseline and are on changed lines.
+func (o *options) filter(dir string, issues []*issue, stderr io.Writer) ([]*issue, error) {
+	if o.baseline != "" {
+		b, err := readBaseline(absPath(dir, o.baseline))
+		if err != nil {
+			return nil, err
+		}
+		var fixed int
+		issues, fixed = b.filter(issues)
+		if fixed > 0 {
+			fmt.Fprintf(stderr, "%d baseline issues are fixed, update the baseline with -baseline-write\n", fixed)
+		}
+	}
+	if o.newFromRev != "" || o.newFromPatch != "" {
+		patch := o.newFromPatch
+		if patch != "" {
+			patch = absPath(dir, patch)
+		}
+		c, err := diffChanges(dir, o.newFromRev, patch)
+		if err != nil {
+			return nil, err
+		}
+		issues = c.filter(issues, o.wholeFunctions)
+	}
+	return issues, nil
+}
+
+// withFlags returns a function creating analyzers of the settings with the analyzer flags set on the command line.
+func withFlags(fs *flag.FlagSet) func(settings map[string]any) (*analysis.Analyzer, error) {
+	return func(settings map[string]any) (*analysis.Analyzer, error) {
+		a := analyzer.NewAnalyzer(settings)
+		var err error
+		// The analyzer applies only its flags set on the command line, so they are set on its own flag set.
+		fs.Visit(func(f *flag.Flag) {
+			if a.Flags.Lookup(f.Name) != nil && err == nil {
+				err = a.Flags.Set(f.Name, f.Value.String())
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrOptions, err)
+		}
+		return a, nil
+	}
+}
+
+// list prints the protected structs of the packages, each resolved with the settings of its directory.
+func list(dir string, args []string, stdout, stderr io.Writer) int {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName | packages.NeedFiles, Dir: dir}, patterns(args)...)
+	if err != nil {
+		fmt.Fprintln(stderr, fmt.Errorf("%w: %w", ErrLoad, err))
+		return exitError
+	}
+	groups, err := groupByConfig(dir, pkgs)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	var names []string
+	for _, g := range groups {
+		paths := make([]string, 0, len(g.pkgs))
+		for _, p := range g.pkgs {
+			paths = append(paths, p.PkgPath)
+		}
+		protected, err := analyzer.ListProtectedStructs(g.settings, dir, paths...)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitError
+		}
+		names = append(names, protected...)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintln(stdout, name)
+	}
+	return exitOK
+}
+
+// analyze loads the packages and returns the issues reported by the analyzers sorted by their position.
+// Packages are grouped by the settings of their directories, each group is analyzed by its own analyzer.
+// Issues of packages compiled with and without tests are reported once.
+func analyze(newAnalyzer func(map[string]any) (*analysis.Analyzer, error), dir string, tests bool,
+	patterns []string,
+) ([]*issue, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir, Tests: tests}, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLoad, err)
+	}
+	var msgs []string
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		for _, e := range p.Errors {
+			msgs = append(msgs, e.Error())
+		}
+	})
+	if len(msgs) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrLoad, strings.Join(msgs, "; "))
+	}
+	groups, err := groupByConfig(dir, pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*issue
+	seen := map[string]bool{}
+	for _, g := range groups {
+		a, err := newAnalyzer(g.settings)
+		if err != nil {
+			return nil, err
+		}
+		graph, err := gochecker.Analyze([]*analysis.Analyzer{a}, g.pkgs, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, act := range graph.Roots {
+			if act.Err != nil {
+				return nil, act.Err
+			}
+			result, _ := act.Result.(*analyzer.Result)
+			for _, d := range act.Diagnostics {
+				is := newIssue(act.Package, d, result)
+				if key := is.Position.String() + "\x00" + is.Message; !seen[key] {
+					seen[key] = true
+					out = append(out, is)
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(out, compareIssues)
+	assignFingerprints(out)
+	return out, nil
+}
+
+func patterns(args []string) []string {
+	if len(args) == 0 {
+		return []string{"./..."}
+	}
+	return args
+}
+
+func absPath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const orderSource = `package order
+
+type Order struct {
+	Status string
+	Total  int
+}
+
+func Reset(o *Order) {
+	o.Status = ""
+	o.Total = 0
+}
+`
+
+const otherSource = "package order\n\ntype Other struct{ Name string }\n"
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// newModule creates a module with the order package in a temporary directory.
+func newModule(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod":         "module example.com/app\n\ngo 1.24\n",
+		".propro.yaml":   "structs: [Order]\n",
+		"order/order.go": orderSource,
+	})
+	return root
+}
+
+func run(t *testing.T, dir string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := Run(dir, args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	root := newModule(t)
+
+	code, _, stderr := run(t, root, "./...")
+	if code != exitIssues {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	for _, want := range []string{
+		"order.go:9:2: assignment to exported field Order.Status is forbidden outside its methods",
+		"order.go:10:2: assignment to exported field Order.Total is forbidden outside its methods",
+	} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("missing %q in:\n%s", want, stderr)
+		}
+	}
+}
+
+func TestRunWithFlags(t *testing.T) {
+	root := newModule(t)
+	writeFiles(t, root, map[string]string{".propro.yaml": "structs: [Order]\n", "order/other.go": otherSource})
+
+	code, _, stderr := run(t, root, "-structs=Other", "./...")
+	if code != exitOK {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+}
+
+func TestRunConfigWarnings(t *testing.T) {
+	root := newModule(t)
+
+	code, _, stderr := run(t, root, "-structs=Order,Missing", "./...")
+	if code != exitIssues || !strings.Contains(stderr, "order.go:1:1: protected struct Missing matches no type") {
+		t.Errorf("exit code %d, stderr:\n%s", code, stderr)
+	}
+}
+
+func TestRunConfigOfPackageDirectory(t *testing.T) {
+	root := newModule(t)
+	writeFiles(t, root, map[string]string{
+		"order/.propro.yaml":   "exclude-structs: [Order]\n",
+		"invoice/invoice.go":   strings.Replace(orderSource, "package order", "package invoice", 1),
+		"invoice/.propro.yaml": "tests: ignore\n",
+	})
+
+	code, _, stderr := run(t, root, "./...")
+	if code != exitIssues {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	want := []string{"invoice.go:9:2", "invoice.go:10:2"}
+	if got := issueLocations(stderr); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, stdout, stderr := run(t, root, "list"); stdout != "example.com/app/invoice.Order\n" {
+		t.Errorf("unexpected protected structs:\n%s\nstderr:\n%s", stdout, stderr)
+	}
+}
+
+func TestRunList(t *testing.T) {
+	root := newModule(t)
+
+	code, stdout, stderr := run(t, root, "list")
+	if code != exitOK {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	if stdout != "example.com/app/order.Order\n" {
+		t.Errorf("unexpected protected structs:\n%s", stdout)
+	}
+}
+
+func TestRunLoadError(t *testing.T) {
+	root := newModule(t)
+	writeFiles(t, root, map[string]string{"order/broken.go": "package order\n\nfunc Broken() { undefined() }\n"})
+
+	if code, _, stderr := run(t, root, "./..."); code != exitError || !strings.Contains(stderr, "undefined") {
+		t.Errorf("exit code %d, stderr:\n%s", code, stderr)
+	}
+}
+
+func TestRunWorkspace(t *testing.T) {
+	t.Setenv("GOFLAGS", "") // workspace mode rejects -mod=mod
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.work":                "go 1.24\n\nuse (\n\t./billing\n\t./shop\n)\n",
+		".propro.yaml":           "protectedPackages: [./order/...]\nregistrationFuncs: [example.com/shop/cart.Register]\n",
+		"billing/go.mod":         "module example.com/billing\n\ngo 1.24\n",
+		"billing/order/order.go": orderSource,
+		"shop/go.mod":            "module example.com/shop\n\ngo 1.24\n",
+		"shop/order/order.go":    orderSource,
+		"shop/cart/cart.go": `package cart
+
+type Cart struct{ Total int }
+
+func Register(any) {}
+
+func init() { Register(&Cart{}) }
+
+func Clear(c *Cart) { c.Total = 0 }
+`,
+	})
+
+	code, _, stderr := run(t, root, "./billing/...", "./shop/...")
+	if code != exitIssues {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	for _, want := range []string{
+		filepath.Join("billing", "order", "order.go") + ":9:2",
+		filepath.Join("shop", "order", "order.go") + ":9:2",
+		filepath.Join("shop", "cart", "cart.go") + ":9:23",
+	} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("missing %s in:\n%s", want, stderr)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strings"
+)
+
+// The flags -json, -c, -cpuprofile, -memprofile and -trace work like those of singlechecker,
+// which the CLI was built on before. go vet -vettool=propro is still handled by singlechecker.
+
+// isVetTool checks whether the arguments are those of go vet -vettool: -flags, -V=full or a single unit config file
+// preceded by analyzer flags.
+func isVetTool(args []string) bool {
+	if len(args) == 1 && (args[0] == "-flags" || strings.HasPrefix(args[0], "-V")) {
+		return true
+	}
+	if len(args) == 0 || !strings.HasSuffix(args[len(args)-1], ".cfg") {
+		return false
+	}
+	for _, arg := range args[:len(args)-1] {
+		if !strings.HasPrefix(arg, "-") {
+			return false
+		}
+	}
+	return true
+}
+
+// vetDiagnostic is a diagnostic in the JSON output of singlechecker and go vet -json.
+type vetDiagnostic struct {
+	Category string `json:"category,omitempty"`
+	Posn     string `json:"posn"`
+	Message  string `json:"message"`
+}
+
+// writeVetJSON writes the issues as a tree from package paths to the analyzer name to its diagnostics.
+func writeVetJSON(w io.Writer, _ string, issues []*issue) error {
+	tree := map[string]map[string][]vetDiagnostic{}
+	for _, is := range issues {
+		if tree[is.Package] == nil {
+			tree[is.Package] = map[string][]vetDiagnostic{}
+		}
+		tree[is.Package][toolName] = append(tree[is.Package][toolName],
+			vetDiagnostic{Category: is.Category, Posn: is.Position.String(), Message: is.Message})
+	}
+	data, err := json.MarshalIndent(tree, "", "\t")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", data); err != nil {
+		return fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	return nil
+}
+
+// textWithContext returns a text formatter printing the offending line of each issue plus the lines of context.
+func textWithContext(lines int) formatter {
+	return func(w io.Writer, _ string, issues []*issue) error {
+		sources := map[string][]string{}
+		for _, is := range issues {
+			if _, err := fmt.Fprintf(w, "%s: %s\n", is.Position, is.Message); err != nil {
+				return fmt.Errorf("%w: %w", ErrFormat, err)
+			}
+			file := is.Position.Filename
+			if _, ok := sources[file]; !ok {
+				data, _ := os.ReadFile(file)
+				sources[file] = strings.Split(string(data), "\n")
+			}
+			for i := max(is.Position.Line-lines, 1); i <= min(is.Position.Line+lines, len(sources[file])); i++ {
+				if _, err := fmt.Fprintf(w, "%d\t%s\n", i, sources[file][i-1]); err != nil {
+					return fmt.Errorf("%w: %w", ErrFormat, err)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// startProfiling starts the CPU profile and the trace requested by the options. The returned function stops them
+// and writes the memory profile.
+func (o *options) startProfiling(dir string) (stop func() error, err error) {
+	var stops []func() error
+	stopAll := func() error {
+		var errs []error
+		for _, s := range stops {
+			errs = append(errs, s())
+		}
+		return errors.Join(errs...)
+	}
+	defer func() {
+		if err != nil {
+			_ = stopAll()
+			err = fmt.Errorf("%w: %w", ErrOptions, err)
+		}
+	}()
+
+	if o.cpuProfile != "" {
+		f, err := os.Create(absPath(dir, o.cpuProfile))
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return nil, errors.Join(err, f.Close())
+		}
+		stops = append(stops, func() error { pprof.StopCPUProfile(); return f.Close() })
+	}
+	if o.trace != "" {
+		f, err := os.Create(absPath(dir, o.trace))
+		if err != nil {
+			return nil, err
+		}
+		if err := trace.Start(f); err != nil {
+			return nil, errors.Join(err, f.Close())
+		}
+		stops = append(stops, func() error { trace.Stop(); return f.Close() })
+	}
+	if o.memProfile != "" {
+		f, err := os.Create(absPath(dir, o.memProfile))
+		if err != nil {
+			return nil, err
+		}
+		stops = append(stops, func() error {
+			runtime.GC() // get up-to-date statistics
+			return errors.Join(pprof.WriteHeapProfile(f), f.Close())
+		})
+	}
+	return stopAll, nil
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSinglecheckerJSON(t *testing.T) {
+	root := newModule(t)
+
+	code, stdout, stderr := run(t, root, "-json", "./...")
+	if code != exitOK {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	var tree map[string]map[string][]vetDiagnostic
+	if err := json.Unmarshal([]byte(stdout), &tree); err != nil {
+		t.Fatal(err)
+	}
+	got := tree["example.com/app/order"][toolName]
+	if len(got) != 2 || got[0].Category != "protected" || !strings.HasSuffix(got[0].Posn, "order.go:9:2") {
+		t.Errorf("unexpected diagnostics:\n%s", stdout)
+	}
+
+	if code, _, stderr := run(t, root, "-json", "-format=sarif", "./..."); code != exitError {
+		t.Errorf("exit code %d, stderr:\n%s", code, stderr)
+	}
+}
+
+func TestSinglecheckerContext(t *testing.T) {
+	root := newModule(t)
+
+	_, _, stderr := run(t, root, "-c=1", "./...")
+	want := "order.go:9:2: assignment to exported field Order.Status is forbidden outside its methods\n" +
+		"8\tfunc Reset(o *Order) {\n9\t\to.Status = \"\"\n10\t\to.Total = 0\n"
+	if !strings.Contains(stderr, want) {
+		t.Errorf("missing context in:\n%s", stderr)
+	}
+}
+
+func TestSinglecheckerProfiles(t *testing.T) {
+	root := newModule(t)
+
+	code, _, stderr := run(t, root, "-cpuprofile=cpu.out", "-memprofile=mem.out", "-trace=trace.out", "./...")
+	if code != exitIssues {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	for _, name := range []string{"cpu.out", "mem.out", "trace.out"} {
+		if info, err := os.Stat(filepath.Join(root, name)); err != nil || info.Size() == 0 {
+			t.Errorf("%s not written: %v", name, err)
+		}
+	}
+
+	if code, _, _ := run(t, root, "-cpuprofile="+filepath.Join(root, "missing", "cpu.out"), "./..."); code != exitError {
+		t.Errorf("exit code %d with an unwritable profile", code)
+	}
+}
+
+func TestVetTool(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the propro binary")
+	}
+	bin := filepath.Join(t.TempDir(), "propro")
+	build := exec.Command("go", "build", "-o", bin, "github.com/digitalstraw/propro/v2/cmd/propro")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+	root := newModule(t)
+	writeFiles(t, root, map[string]string{".propro.yaml": "structs: [Other]\n", "order/other.go": otherSource})
+
+	vet := exec.Command("go", "vet", "-vettool="+bin, "-structs=Order", "./...")
+	vet.Dir = root
+	out, err := vet.CombinedOutput()
+	if err == nil || !strings.Contains(string(out), "assignment to exported field Order.Status is forbidden outside its methods") {
+		t.Errorf("go vet: %v\n%s", err, out)
+	}
+}
+
+func TestIsVetTool(t *testing.T) {
+	for args, want := range map[string]bool{
+		"-flags":                      true,
+		"-V=full":                     true,
+		"/tmp/vet.cfg":                true,
+		"-structs=Order /tmp/vet.cfg": true,
+		"./...":                       false,
+		"-json ./...":                 false,
+		"./a vet.cfg":                 false,
+	} {
+		if got := isVetTool(strings.Fields(args)); got != want {
+			t.Errorf("isVetTool(%q) = %v, want %v", args, got, want)
+		}
+	}
+}
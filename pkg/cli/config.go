@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/digitalstraw/propro/v2/pkg/analyzer"
+	"golang.org/x/tools/go/packages"
+)
+
+// configGroup is a set of packages sharing the settings of their directories.
+type configGroup struct {
+	settings map[string]any
+	pkgs     []*packages.Package
+}
+
+// groupByConfig groups the packages by the settings merged from the .propro.yaml files of their directories
+// up to the filesystem root, see analyzer.FindConfig. So a .propro.yaml of a subdirectory applies to its packages
+// even when propro runs from the repository root. The groups are in the order of their first packages.
+func groupByConfig(dir string, pkgs []*packages.Package) ([]*configGroup, error) {
+	var groups []*configGroup
+	byKey := map[string]*configGroup{}
+	byDir := map[string]*configGroup{}
+	for _, p := range pkgs {
+		pkgDir := packageDir(dir, p)
+		if g, ok := byDir[pkgDir]; ok {
+			g.pkgs = append(g.pkgs, p)
+			continue
+		}
+		settings, err := analyzer.FindConfig(pkgDir)
+		if err != nil {
+			return nil, err
+		}
+		key, err := json.Marshal(settings)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", analyzer.ErrConfigFile, err)
+		}
+		g, ok := byKey[string(key)]
+		if !ok {
+			g = &configGroup{settings: settings}
+			byKey[string(key)] = g
+			groups = append(groups, g)
+		}
+		byDir[pkgDir] = g
+		g.pkgs = append(g.pkgs, p)
+	}
+	return groups, nil
+}
+
+// packageDir returns the directory of the package files, or the directory propro runs in.
+func packageDir(dir string, p *packages.Package) string {
+	switch {
+	case p.Dir != "":
+		return p.Dir
+	case len(p.GoFiles) > 0:
+		return filepath.Dir(p.GoFiles[0])
+	}
+	return dir
+}
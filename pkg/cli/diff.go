@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var ErrDiff = errors.New("changes cannot be determined")
+
+// changes are lines changed in files, keyed by absolute file paths with resolved symlinks.
+type changes map[string]*fileChanges
+
+type fileChanges struct {
+	added   map[int]bool // added or modified lines of the new file
+	deleted []int        // lines of the new file before which lines were deleted
+	whole   bool         // the file is new and not tracked
+}
+
+// diffChanges returns the lines changed since the git revision, including uncommitted and untracked files,
+// or the lines changed by the patch file if the revision is empty. Paths in the diff are relative to the root
+// of the git repository of the directory, or to the directory outside of git repositories.
+func diffChanges(dir, rev, patch string) (changes, error) {
+	root := dir
+	if out, err := git(dir, "rev-parse", "--show-toplevel"); err == nil {
+		root = strings.TrimSpace(out)
+	} else if rev != "" {
+		return nil, err
+	}
+
+	var diff io.Reader
+	if rev != "" {
+		out, err := git(dir, "diff", "--no-color", "--no-ext-diff", "--no-renames", "--src-prefix=a/", "--dst-prefix=b/",
+			"-U0", rev, "--")
+		if err != nil {
+			return nil, err
+		}
+		diff = strings.NewReader(out)
+	} else {
+		data, err := os.ReadFile(patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDiff, err)
+		}
+		diff = bytes.NewReader(data)
+	}
+	out, err := parseDiff(diff, root)
+	if err != nil {
+		return nil, err
+	}
+
+	if rev != "" {
+		untracked, err := git(dir, "ls-files", "--others", "--exclude-standard", "--full-name", "--", root)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range strings.Fields(untracked) {
+			out[canonicalPath(filepath.Join(root, name))] = &fileChanges{whole: true}
+		}
+	}
+	return out, nil
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: git %s: %w: %s", ErrDiff, args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// parseDiff parses the unified diff with paths relative to the root. Lines of hunks are counted by their headers,
+// so that removed lines like "-- x" and added lines like "++ x" are not taken for file headers.
+func parseDiff(r io.Reader, root string) (changes, error) {
+	out := changes{}
+	var file *fileChanges
+	var h hunk
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<24)
+	for scanner.Scan() {
+		text := scanner.Text()
+		switch {
+		case !h.done():
+			h.scan(file, text)
+		case strings.HasPrefix(text, "+++ "):
+			file = nil
+			name := diffPath(strings.TrimPrefix(text, "+++ "))
+			if name == "/dev/null" {
+				continue
+			}
+			file = &fileChanges{added: map[int]bool{}}
+			out[canonicalPath(filepath.Join(root, name))] = file
+		case strings.HasPrefix(text, "@@ "):
+			var err error
+			if h, err = parseHunk(text); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDiff, err)
+	}
+	if !h.done() {
+		return nil, fmt.Errorf("%w: truncated hunk", ErrDiff)
+	}
+	return out, nil
+}
+
+// diffPath returns the path of the file header without the b/ prefix and trailing timestamp.
+func diffPath(name string) string {
+	if unquoted, err := strconv.Unquote(name); err == nil {
+		name = unquoted
+	} else if i := strings.IndexByte(name, '\t'); i >= 0 {
+		name = name[:i]
+	}
+	return strings.TrimPrefix(name, "b/")
+}
+
+// hunk tracks the lines of a hunk which remain to be read.
+type hunk struct {
+	line    int // the current line of the new file
+	oldLeft int // remaining lines of the old file
+	newLeft int // remaining lines of the new file
+}
+
+// parseHunk parses the hunk header like @@ -1,2 +3,4 @@.
+// Hunks without lines of the new file start after the given line.
+func parseHunk(header string) (hunk, error) {
+	invalid := fmt.Errorf("%w: invalid hunk header %q", ErrDiff, header)
+	fields := strings.Fields(header)
+	if len(fields) < 4 || fields[3] != "@@" || !strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return hunk{}, invalid
+	}
+	_, oldCount, err := hunkRange(fields[1][1:])
+	if err != nil {
+		return hunk{}, invalid
+	}
+	start, newCount, err := hunkRange(fields[2][1:])
+	if err != nil {
+		return hunk{}, invalid
+	}
+	if newCount == 0 {
+		start++
+	}
+	return hunk{line: start, oldLeft: oldCount, newLeft: newCount}, nil
+}
+
+func (h *hunk) done() bool {
+	return h.oldLeft <= 0 && h.newLeft <= 0
+}
+
+// scan records the line of the hunk in the file changes, unless the file is deleted.
+func (h *hunk) scan(file *fileChanges, text string) {
+	switch {
+	case strings.HasPrefix(text, "+"):
+		if file != nil {
+			file.added[h.line] = true
+		}
+		h.line++
+		h.newLeft--
+	case strings.HasPrefix(text, "-"):
+		if file != nil {
+			file.deleted = append(file.deleted, h.line)
+		}
+		h.oldLeft--
+	case strings.HasPrefix(text, `\`): // \ No newline at end of file
+	default: // context line, possibly with the trailing space stripped
+		h.line++
+		h.oldLeft--
+		h.newLeft--
+	}
+}
+
+// hunkRange parses the range like 3,4 of a hunk header. The count defaults to 1.
+func hunkRange(s string) (start, count int, err error) {
+	first, n, found := strings.Cut(s, ",")
+	if start, err = strconv.Atoi(first); err != nil {
+		return 0, 0, err
+	}
+	count = 1
+	if found {
+		if count, err = strconv.Atoi(n); err != nil {
+			return 0, 0, err
+		}
+	}
+	if start < 0 || count < 0 {
+		return 0, 0, strconv.ErrRange
+	}
+	return start, count, nil
+}
+
+// changed checks whether the line of the file, or with whole functions any line of the enclosing function, is changed.
+func (c changes) changed(is *issue, wholeFunctions bool) bool {
+	file := c[canonicalPath(is.Position.Filename)]
+	switch {
+	case file == nil:
+		return false
+	case file.whole || file.added[is.Position.Line]:
+		return true
+	case !wholeFunctions || is.funcStart == 0:
+		return false
+	}
+	for line := is.funcStart; line <= is.funcEnd; line++ {
+		if file.added[line] {
+			return true
+		}
+	}
+	for _, line := range file.deleted {
+		if line > is.funcStart && line <= is.funcEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// filter returns the issues on changed lines.
+func (c changes) filter(issues []*issue, wholeFunctions bool) []*issue {
+	var out []*issue
+	for _, is := range issues {
+		if c.changed(is, wholeFunctions) {
+			out = append(out, is)
+		}
+	}
+	return out
+}
+
+func canonicalPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
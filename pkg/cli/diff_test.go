@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const orderChanged = `package order
+
+type Order struct {
+	Status string
+	Total  int
+}
+
+func Reset(o *Order) {
+	o.Status = ""
+	o.Total = 0
+	o.Status = "reset"
+}
+
+func Clear(o *Order) {
+	o.Total = 0
+}
+`
+
+func TestParseDiff(t *testing.T) {
+	diff := `diff --git a/order/order.go b/order/order.go
+--- a/order/order.go	2026-01-01 00:00:00
++++ b/order/order.go	2026-01-02 00:00:00
+@@ -8,4 +8,4 @@ type Order struct {
+ func Reset(o *Order) {
+-	o.Status = ""
++	o.Status = "new"
+ 	o.Total = 0
+ }
+@@ -20,2 +19,0 @@ func Other() {
+-	a()
+-	b()
+--- a/removed.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package removed
+`
+	got, err := parseDiff(strings.NewReader(diff), "/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := changes{"/repo/order/order.go": {added: map[int]bool{9: true}, deleted: []int{9, 20, 20}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got["/repo/order/order.go"], want["/repo/order/order.go"])
+	}
+}
+
+func TestParseDiffHeaderLikeLines(t *testing.T) {
+	// -U0 hunks removing the line "-- a" and adding the line "++ b" look like file headers.
+	diff := `--- a/query.sql
++++ b/query.sql
+@@ -3 +2,0 @@
+--- a
+@@ -5,0 +5,2 @@
++++ b
++select 1;
+\ No newline at end of file
+`
+	got, err := parseDiff(strings.NewReader(diff), "/repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := changes{"/repo/query.sql": {added: map[int]bool{5: true, 6: true}, deleted: []int{3}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got["/repo/query.sql"], want["/repo/query.sql"])
+	}
+}
+
+func TestParseDiffInvalidHunk(t *testing.T) {
+	for _, diff := range []string{
+		"+++ b/a.go\n@@ -1 @@\n",
+		"+++ b/a.go\n@@ -1,x +1 @@\n",
+		"+++ b/a.go\n@@ -1,2 +1,2 @@\n a\n",
+	} {
+		if _, err := parseDiff(strings.NewReader(diff), "/repo"); err == nil {
+			t.Errorf("expected error for %q", diff)
+		}
+	}
+}
+
+func gitRun(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v\n%s", args[0], err, out)
+	}
+}
+
+// newRepo creates a git repository with the committed module and changes the order package.
+func newRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+	root := newModule(t)
+	gitRun(t, root, "init", "-q")
+	gitRun(t, root, "add", "-A")
+	gitRun(t, root, "commit", "-q", "-m", "init")
+	writeFiles(t, root, map[string]string{
+		"order/order.go": orderChanged,
+		"order/new.go":   "package order\n\nfunc Cancel(o *Order) {\n\to.Status = \"cancelled\"\n}\n",
+	})
+	return root
+}
+
+func TestNewFromRev(t *testing.T) {
+	root := newRepo(t)
+
+	code, _, stderr := run(t, root, "-new-from-rev=HEAD", "./...")
+	if code != exitIssues {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	want := []string{"new.go:4:2", "order.go:11:2", "order.go:15:2"}
+	if got := issueLocations(stderr); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	code, _, stderr = run(t, root, "-new-from-rev=HEAD", "-new-whole-functions", "./...")
+	if code != exitIssues {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	want = []string{"new.go:4:2", "order.go:9:2", "order.go:10:2", "order.go:11:2", "order.go:15:2"}
+	if got := issueLocations(stderr); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestNewFromPatch(t *testing.T) {
+	root := newModule(t)
+	writeFiles(t, root, map[string]string{
+		"order/order.go": orderChanged,
+		"changes.diff": `--- a/order/order.go
++++ b/order/order.go
+@@ -14,0 +15 @@
++	o.Total = 0
+`,
+	})
+
+	code, _, stderr := run(t, root, "-new-from-patch=changes.diff", "./...")
+	if code != exitIssues {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	if got := issueLocations(stderr); !reflect.DeepEqual(got, []string{"order.go:15:2"}) {
+		t.Errorf("unexpected issues %v", got)
+	}
+}
+
+func TestNewFromInvalid(t *testing.T) {
+	root := newModule(t)
+
+	if code, _, stderr := run(t, root, "-new-from-rev=HEAD", "-new-from-patch=a.diff"); code != exitError {
+		t.Errorf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	if code, _, stderr := run(t, root, "-new-from-patch=missing.diff"); code != exitError ||
+		!strings.Contains(stderr, ErrDiff.Error()) {
+		t.Errorf("exit code %d, stderr:\n%s", code, stderr)
+	}
+}
+
+// issueLocations returns file:line:col of the reported issues.
+func issueLocations(stderr string) []string {
+	var out []string
+	for line := range strings.Lines(stderr) {
+		location, _, _ := strings.Cut(line, ": ")
+		out = append(out, filepath.Base(location))
+	}
+	return out
+}
@@ -0,0 +1,388 @@
+package cli
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/digitalstraw/propro/v2/pkg/analyzer"
+)
+
+const (
+	toolName = "propro"
+	toolURI  = "https://github.com/digitalstraw/propro"
+)
+
+var ErrFormat = errors.New("report cannot be written")
+
+// formatter writes the issues in a report format. Paths are relative to the directory if they are inside it.
+type formatter func(w io.Writer, dir string, issues []*issue) error
+
+var formatters = map[string]formatter{
+	"text":           writeText,
+	"json":           writeJSON,
+	"sarif":          writeSARIF,
+	"checkstyle":     writeCheckstyle,
+	"junit":          writeJUnit,
+	"github-actions": writeGitHubActions,
+}
+
+func formatNames() string {
+	return strings.Join(slices.Sorted(maps.Keys(formatters)), ", ")
+}
+
+func writeText(w io.Writer, _ string, issues []*issue) error {
+	for _, is := range issues {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", is.Position, is.Message); err != nil {
+			return fmt.Errorf("%w: %w", ErrFormat, err)
+		}
+	}
+	return nil
+}
+
+type jsonReport struct {
+	Issues []jsonIssue `json:"issues"`
+}
+
+type jsonIssue struct {
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Column      int    `json:"column"`
+	Message     string `json:"message"`
+	Category    string `json:"category"`
+	Package     string `json:"package"`
+	Struct      string `json:"struct,omitempty"`
+	Field       string `json:"field,omitempty"`
+	Func        string `json:"function,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+func writeJSON(w io.Writer, dir string, issues []*issue) error {
+	report := jsonReport{Issues: make([]jsonIssue, 0, len(issues))}
+	for _, is := range issues {
+		report.Issues = append(report.Issues, jsonIssue{
+			File:        relPath(dir, is.Position.Filename),
+			Line:        is.Position.Line,
+			Column:      is.Position.Column,
+			Message:     is.Message,
+			Category:    is.Category,
+			Package:     is.Package,
+			Struct:      is.Struct,
+			Field:       is.Field,
+			Func:        is.Func,
+			Fingerprint: is.Fingerprint,
+		})
+	}
+	return encodeJSON(w, report)
+}
+
+func encodeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	return nil
+}
+
+// properties returns the structured properties of the issue for formats with custom properties.
+func (is *issue) properties() map[string]string {
+	out := map[string]string{"kind": is.Category, "package": is.Package}
+	if is.Struct != "" {
+		out["struct"] = is.Struct
+	}
+	if is.Field != "" {
+		out["field"] = is.Field
+	}
+	if is.Func != "" {
+		out["function"] = is.Func
+	}
+	return out
+}
+
+// level returns the severity of the issue. Configuration issues are warnings.
+func (is *issue) level() string {
+	if is.Category == "config" {
+		return "warning"
+	}
+	return "error"
+}
+
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           *int              `json:"ruleIndex,omitempty"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]string `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+func writeSARIF(w io.Writer, dir string, issues []*issue) error {
+	driver := sarifDriver{Name: toolName, InformationURI: toolURI}
+	ruleIndex := map[string]int{}
+	for i, rule := range analyzer.Rules() {
+		ruleIndex[rule.ID] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:               rule.ID,
+			ShortDescription: sarifMessage{Text: rule.Description},
+			HelpURI:          toolURI + "#readme",
+		})
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: make([]sarifResult, 0, len(issues))}
+	for _, is := range issues {
+		file := relPath(dir, is.Position.Filename)
+		artifact := sarifArtifactLocation{URI: file}
+		if !filepath.IsAbs(file) {
+			artifact.URIBaseID = "%SRCROOT%"
+		}
+		result := sarifResult{
+			RuleID:  is.Category,
+			Level:   is.level(),
+			Message: sarifMessage{Text: is.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: artifact,
+				Region:           sarifRegion{StartLine: is.Position.Line, StartColumn: is.Position.Column},
+			}}},
+			PartialFingerprints: map[string]string{"propro/v1": is.Fingerprint},
+			Properties:          is.properties(),
+		}
+		if i, ok := ruleIndex[is.Category]; ok {
+			result.RuleIndex = &i
+		}
+		run.Results = append(run.Results, result)
+	}
+	return encodeJSON(w, sarifReport{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+// See https://checkstyle.org. Unknown attributes of errors are ignored by checkstyle consumers.
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+	Struct   string `xml:"struct,attr,omitempty"`
+	Field    string `xml:"field,attr,omitempty"`
+	Func     string `xml:"function,attr,omitempty"`
+}
+
+func writeCheckstyle(w io.Writer, dir string, issues []*issue) error {
+	report := checkstyleReport{Version: "5.0"}
+	for _, is := range issues {
+		name := relPath(dir, is.Position.Filename)
+		if len(report.Files) == 0 || report.Files[len(report.Files)-1].Name != name {
+			report.Files = append(report.Files, checkstyleFile{Name: name})
+		}
+		file := &report.Files[len(report.Files)-1]
+		file.Errors = append(file.Errors, checkstyleError{
+			Line:     is.Position.Line,
+			Column:   is.Position.Column,
+			Severity: is.level(),
+			Message:  is.Message,
+			Source:   toolName + "." + is.Category,
+			Struct:   is.Struct,
+			Field:    is.Field,
+			Func:     is.Func,
+		})
+	}
+	return encodeXML(w, report)
+}
+
+func encodeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("%w: %w", ErrFormat, err)
+	}
+	return nil
+}
+
+// A test suite per package, a failed test case per issue.
+type junitReport struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name       string          `xml:"name,attr"`
+	Classname  string          `xml:"classname,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Failure    junitFailure    `xml:"failure"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func writeJUnit(w io.Writer, dir string, issues []*issue) error {
+	report := junitReport{Tests: len(issues), Failures: len(issues)}
+	suites := map[string]int{}
+	for _, is := range issues {
+		i, ok := suites[is.Package]
+		if !ok {
+			i = len(report.Suites)
+			suites[is.Package] = i
+			report.Suites = append(report.Suites, junitSuite{Name: is.Package})
+		}
+		location := fmt.Sprintf("%s:%d:%d", relPath(dir, is.Position.Filename), is.Position.Line, is.Position.Column)
+		report.Suites[i].Tests++
+		report.Suites[i].Failures++
+		report.Suites[i].Cases = append(report.Suites[i].Cases, junitCase{
+			Name:       location,
+			Classname:  is.Package,
+			Properties: junitProperties(is.properties()),
+			Failure:    junitFailure{Message: is.Message, Type: is.Category, Text: location + ": " + is.Message},
+		})
+	}
+	return encodeXML(w, report)
+}
+
+func junitProperties(properties map[string]string) []junitProperty {
+	out := make([]junitProperty, 0, len(properties))
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		out = append(out, junitProperty{Name: name, Value: properties[name]})
+	}
+	return out
+}
+
+// writeGitHubActions writes workflow commands annotating the issues,
+// see https://docs.github.com/actions/reference/workflow-commands-for-github-actions.
+func writeGitHubActions(w io.Writer, dir string, issues []*issue) error {
+	for _, is := range issues {
+		var details []string
+		for _, p := range [][2]string{{"struct", is.Struct}, {"field", is.Field}, {"function", is.Func}} {
+			if p[1] != "" {
+				details = append(details, p[0]+": "+p[1])
+			}
+		}
+		message := is.Message
+		if len(details) > 0 {
+			message += "\n" + strings.Join(details, ", ")
+		}
+		_, err := fmt.Fprintf(w, "::%s file=%s,line=%d,col=%d,title=%s::%s\n", is.level(),
+			escapeProperty(filepath.ToSlash(relPath(dir, is.Position.Filename))), is.Position.Line, is.Position.Column,
+			escapeProperty(toolName+" ("+is.Category+")"), escapeData(message))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrFormat, err)
+		}
+	}
+	return nil
+}
+
+var (
+	dataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	propertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func escapeData(s string) string {
+	return dataEscaper.Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return propertyEscaper.Replace(s)
+}
+
+// relPath returns the path relative to the directory with slashes, or the path if it is outside the directory.
+func relPath(dir, path string) string {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
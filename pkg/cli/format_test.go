@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatJSON(t *testing.T) {
+	root := newModule(t)
+
+	code, stdout, stderr := run(t, root, "-format=json", "./...")
+	if code != exitIssues {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	var report jsonReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Issues) != 2 {
+		t.Fatalf("expected 2 issues, got:\n%s", stdout)
+	}
+	got := report.Issues[0]
+	if got.File != "order/order.go" || got.Line != 9 || got.Column != 2 || got.Category != "protected" ||
+		got.Struct != "Order" || got.Field != "Status" || got.Func != "Reset" || got.Fingerprint == "" {
+		t.Errorf("unexpected issue %+v", got)
+	}
+}
+
+func TestFormatSARIF(t *testing.T) {
+	root := newModule(t)
+
+	code, stdout, stderr := run(t, root, "-format=sarif", "./...")
+	if code != exitIssues {
+		t.Fatalf("exit code %d, stderr:\n%s", code, stderr)
+	}
+	var report sarifReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Version != "2.1.0" || len(report.Runs) != 1 || len(report.Runs[0].Results) != 2 {
+		t.Fatalf("unexpected report:\n%s", stdout)
+	}
+	run := report.Runs[0]
+	got := run.Results[0]
+	if got.RuleIndex == nil || run.Tool.Driver.Rules[*got.RuleIndex].ID != "protected" || got.RuleID != "protected" {
+		t.Errorf("unexpected rule of %+v", got)
+	}
+	if got.PartialFingerprints["propro/v1"] == "" ||
+		got.Locations[0].PhysicalLocation.ArtifactLocation.URI != "order/order.go" {
+		t.Errorf("unexpected result %+v", got)
+	}
+	want := map[string]string{
+		"kind": "protected", "package": "example.com/app/order", "struct": "Order", "field": "Status", "function": "Reset",
+	}
+	for key, value := range want {
+		if got.Properties[key] != value {
+			t.Errorf("property %s: got %q, want %q", key, got.Properties[key], value)
+		}
+	}
+}
+
+func TestFormatCheckstyle(t *testing.T) {
+	root := newModule(t)
+
+	_, stdout, _ := run(t, root, "-format=checkstyle", "./...")
+	var report checkstyleReport
+	if err := xml.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Files) != 1 || report.Files[0].Name != "order/order.go" || len(report.Files[0].Errors) != 2 {
+		t.Fatalf("unexpected report:\n%s", stdout)
+	}
+	got := report.Files[0].Errors[1]
+	if got.Line != 10 || got.Source != "propro.protected" || got.Severity != "error" ||
+		got.Struct != "Order" || got.Field != "Total" || got.Func != "Reset" {
+		t.Errorf("unexpected error %+v", got)
+	}
+}
+
+func TestFormatJUnit(t *testing.T) {
+	root := newModule(t)
+
+	_, stdout, _ := run(t, root, "-format=junit", "./...")
+	var report junitReport
+	if err := xml.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Failures != 2 || len(report.Suites) != 1 || len(report.Suites[0].Cases) != 2 {
+		t.Fatalf("unexpected report:\n%s", stdout)
+	}
+	got := report.Suites[0].Cases[0]
+	if got.Name != "order/order.go:9:2" || got.Failure.Type != "protected" || len(got.Properties) != 5 {
+		t.Errorf("unexpected test case %+v", got)
+	}
+}
+
+func TestFormatGitHubActions(t *testing.T) {
+	root := newModule(t)
+
+	_, stdout, _ := run(t, root, "-format=github-actions", "./...")
+	want := "::error file=order/order.go,line=9,col=2,title=propro (protected)::" +
+		"assignment to exported field Order.Status is forbidden outside its methods%0Astruct: Order, field: Status, function: Reset\n"
+	if !strings.HasPrefix(stdout, want) {
+		t.Errorf("unexpected annotations:\n%s", stdout)
+	}
+}
+
+func TestOutputAndExitCodes(t *testing.T) {
+	root := newModule(t)
+
+	code, stdout, stderr := run(t, root, "-format=json", "-output=report.json", "-exit-zero", "./...")
+	if code != exitOK || stdout != "" {
+		t.Fatalf("exit code %d, stdout:\n%s\nstderr:\n%s", code, stdout, stderr)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "report.json"))
+	if err != nil || !strings.Contains(string(data), `"fingerprint"`) {
+		t.Errorf("unexpected report %s: %v", data, err)
+	}
+
+	if code, _, _ := run(t, root, "-max-issues=2", "./..."); code != exitOK {
+		t.Errorf("exit code %d with 2 allowed issues", code)
+	}
+	if code, _, _ := run(t, root, "-max-issues=1", "./..."); code != exitIssues {
+		t.Errorf("exit code %d with 1 allowed issue", code)
+	}
+	if code, _, stderr := run(t, root, "-format=html", "./..."); code != exitError || !strings.Contains(stderr, "unknown format") {
+		t.Errorf("exit code %d, stderr:\n%s", code, stderr)
+	}
+}
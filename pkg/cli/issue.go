@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/digitalstraw/propro/v2/pkg/analyzer"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/packages"
+)
+
+// issue is a reported diagnostic with the structured properties of the analyzer issue.
+type issue struct {
+	Position    token.Position
+	Message     string
+	Category    string
+	Package     string
+	Struct      string
+	Field       string
+	Func        string
+	Statement   string // whitespace-normalized source of the statement containing the issue
+	Fingerprint string // identifies the issue independently of its position, see assignFingerprints
+
+	funcStart, funcEnd int // lines of the enclosing function declaration, zero outside functions
+}
+
+func newIssue(pkg *packages.Package, d analysis.Diagnostic, result *analyzer.Result) *issue {
+	is := &issue{
+		Position: pkg.Fset.Position(d.Pos),
+		Message:  d.Message,
+		Category: d.Category,
+		Package:  pkg.PkgPath,
+	}
+	if result != nil {
+		for _, r := range result.Issues {
+			if r.Pos == d.Pos && r.Message == d.Message {
+				is.Struct, is.Field, is.Func = r.Struct, r.Field, r.Func
+				break
+			}
+		}
+	}
+
+	path := enclosingPath(pkg, d.Pos)
+	is.Statement = statementText(pkg.Fset, path)
+	for _, n := range path {
+		if fn, ok := n.(*ast.FuncDecl); ok {
+			is.funcStart, is.funcEnd = pkg.Fset.Position(fn.Pos()).Line, pkg.Fset.Position(fn.End()).Line
+		}
+	}
+	return is
+}
+
+// enclosingPath returns the nodes enclosing the position, from the innermost to the file.
+func enclosingPath(pkg *packages.Package, pos token.Pos) []ast.Node {
+	for _, f := range pkg.Syntax {
+		if pos >= f.FileStart && pos <= f.FileEnd {
+			path, _ := astutil.PathEnclosingInterval(f, pos, pos)
+			return path
+		}
+	}
+	return nil
+}
+
+// statementText returns the normalized source of the innermost statement or spec of the path,
+// or an empty string if there is none, e.g. for issues reported at declarations.
+func statementText(fset *token.FileSet, path []ast.Node) string {
+	for _, n := range path {
+		switch n.(type) {
+		case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+			continue
+		case ast.Stmt, ast.Spec:
+			var buf bytes.Buffer
+			if err := printer.Fprint(&buf, fset, n); err != nil {
+				return ""
+			}
+			return strings.Join(strings.Fields(buf.String()), " ")
+		case ast.Decl:
+			return ""
+		}
+	}
+	return ""
+}
+
+// assignFingerprints sets fingerprints of the issues sorted by position. A fingerprint hashes the package, violation
+// kind, struct, field, enclosing function and statement, not the position, so it is stable when unrelated code is
+// added or moved. Identical issues in one function are told apart by their occurrence index.
+func assignFingerprints(issues []*issue) {
+	occurrences := map[string]int{}
+	for _, is := range issues {
+		parts := []string{is.Package, is.Category, is.Struct, is.Field, is.Func, is.Statement}
+		if is.Struct == "" {
+			parts = append(parts, is.Message)
+		}
+		key := strings.Join(parts, "\x00")
+		sum := sha256.Sum256([]byte(key + "\x00" + strconv.Itoa(occurrences[key])))
+		occurrences[key]++
+		is.Fingerprint = hex.EncodeToString(sum[:16])
+	}
+}
+
+func compareIssues(a, b *issue) int {
+	return cmp.Or(
+		cmp.Compare(a.Position.Filename, b.Position.Filename),
+		cmp.Compare(a.Position.Line, b.Position.Line),
+		cmp.Compare(a.Position.Column, b.Position.Column),
+		cmp.Compare(a.Message, b.Message),
+	)
+}
@@ -0,0 +1,41 @@
+// Package plugin registers propro as a golangci-lint module plugin, see https://golangci-lint.run/plugins/module-plugins/.
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/digitalstraw/propro/v2/pkg/analyzer"
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+)
+
+const name = "propro"
+
+func init() {
+	register.Plugin(name, New)
+}
+
+type plugin struct {
+	settings map[string]any
+}
+
+// New creates the plugin from the settings of the linter, e.g. linters.settings.custom.propro.settings.
+// The settings are validated here, so misconfiguration is reported before any package is analyzed.
+func New(settings any) (register.LinterPlugin, error) {
+	s, err := register.DecodeSettings[map[string]any](settings)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if _, err := analyzer.DecodeConfig(s); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &plugin{settings: s}, nil
+}
+
+func (p *plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{analyzer.NewAnalyzer(p.settings)}, nil
+}
+
+func (p *plugin) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
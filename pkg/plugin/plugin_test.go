@@ -0,0 +1,161 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/digitalstraw/propro/v2/pkg/analyzer"
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func repoRoot() string {
+	path, _ := os.Getwd()
+	return filepath.Dir(filepath.Dir(path))
+}
+
+func TestPlugin(t *testing.T) {
+	newPlugin, err := register.GetPlugin(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// golangci-lint lowercases keys of the settings.
+	p, err := newPlugin(map[string]any{"structs": []any{"Entity", "SubEntity"}, "entitylistvars": "EntityList"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := p.GetLoadMode(); mode != register.LoadModeTypesInfo {
+		t.Errorf("GetLoadMode() = %q, want %q", mode, register.LoadModeTypesInfo)
+	}
+	analyzers, err := p.BuildAnalyzers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	analysistest.Run(t, filepath.Join(repoRoot(), "testdata"), analyzers[0], "protectselected")
+}
+
+func TestPluginWithInvalidSettings(t *testing.T) {
+	_, err := New(map[string]any{"struct": []any{"Entity"}})
+	if !errors.Is(err, analyzer.ErrUnknownConfigKey) {
+		t.Errorf("New() error = %v, want %v", err, analyzer.ErrUnknownConfigKey)
+	}
+}
+
+// registeringMain registers the plugin by a blank import like binaries built by `golangci-lint custom` do, but runs
+// the analyzer by singlechecker. It is not golangci-lint: neither .custom-gcl.yml nor its plugin loading is involved.
+const registeringMain = `package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	_ "github.com/digitalstraw/propro/v2/pkg/plugin"
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	var settings any
+	if err := json.Unmarshal([]byte(os.Getenv("PROPRO_SETTINGS")), &settings); err != nil {
+		log.Fatal(err)
+	}
+	newPlugin, err := register.GetPlugin("propro")
+	if err != nil {
+		log.Fatal(err)
+	}
+	p, err := newPlugin(settings)
+	if err != nil {
+		log.Fatal(err)
+	}
+	analyzers, err := p.BuildAnalyzers()
+	if err != nil {
+		log.Fatal(err)
+	}
+	singlechecker.Main(analyzers[0])
+}
+`
+
+// TestPluginRegisteredByBlankImport builds a binary registering the plugin by a blank import, outside of this module,
+// and checks the settings reach the analyzer. The modules are provisioned from the download cache into a fresh module
+// cache, so the test does not depend on the network nor on the extracted module cache.
+func TestPluginRegisteredByBlankImport(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a binary")
+	}
+	root := repoRoot()
+	dir := t.TempDir()
+
+	goSum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	goMod := "module example.com/registering\n\ngo 1.24.0\n\n" +
+		"require (\n" +
+		"\tgithub.com/digitalstraw/propro/v2 v2.0.0\n" +
+		"\tgithub.com/golangci/plugin-module-register v0.1.2\n" +
+		"\tgolang.org/x/tools v0.39.0\n" +
+		")\n\n" +
+		"replace github.com/digitalstraw/propro/v2 => " + filepath.ToSlash(root) + "\n"
+	for file, content := range map[string][]byte{"go.mod": []byte(goMod), "go.sum": goSum, "main.go": []byte(registeringMain)} {
+		if err := os.WriteFile(filepath.Join(dir, file), content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	env := provisionModules(t, dir)
+
+	bin := filepath.Join(dir, "registering")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = dir
+	build.Env = env
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+
+	settings, _ := json.Marshal(map[string]any{"structs": []string{"Entity"}})
+	run := exec.Command(bin, "./testdata/src/protectselected")
+	run.Dir = root
+	run.Env = append(env, "PROPRO_SETTINGS="+string(settings))
+	out, _ := run.CombinedOutput()
+
+	if !strings.Contains(string(out), "assignment to exported field Entity.ProtectedField is forbidden") {
+		t.Errorf("expected diagnostic of Entity, got:\n%s", out)
+	}
+	if strings.Contains(string(out), "SubEntity.ProtectedField") {
+		t.Errorf("unexpected diagnostic of SubEntity not listed in settings:\n%s", out)
+	}
+}
+
+// provisionModules downloads the modules required by the module in dir from the local download cache, used as
+// a file proxy, into a fresh module cache. It returns the environment of go commands using them. The modules are
+// those required to build the repository, so they are in the download cache whenever its tests can be built.
+func provisionModules(t *testing.T, dir string) []string {
+	t.Helper()
+	out, err := exec.Command("go", "env", "GOMODCACHE").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy := filepath.Join(strings.TrimSpace(string(out)), "cache", "download")
+	env := append(os.Environ(),
+		"GOPROXY=file:///"+strings.TrimPrefix(filepath.ToSlash(proxy), "/"),
+		"GOMODCACHE="+filepath.Join(t.TempDir(), "mod"),
+		"GOFLAGS=-mod=mod -modcacherw",
+		"GOWORK=off",
+		"GOSUMDB=off",
+		"GOTOOLCHAIN=local",
+	)
+
+	download := exec.Command("go", "mod", "download")
+	download.Dir = dir
+	download.Env = env
+	if out, err := download.CombinedOutput(); err != nil {
+		t.Fatalf("modules are not in the download cache %s, run go mod download: %v\n%s", proxy, err, out)
+	}
+	return env
+}
@@ -0,0 +1,7 @@
+package ddd
+
+import "example.com/implements/ids"
+
+type Entity interface {
+	ID() ids.ID
+}
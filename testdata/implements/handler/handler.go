@@ -0,0 +1,8 @@
+package handler
+
+import "example.com/implements/users"
+
+func Rename(u *users.User, g *users.Guest) {
+	u.Name = "x" // want "assignment to exported field User.Name is forbidden outside its methods"
+	g.Name = "x"
+}
@@ -0,0 +1,3 @@
+package ids
+
+type ID string
@@ -0,0 +1,22 @@
+package users
+
+import "example.com/implements/ids"
+
+// User implements ddd.Entity, but neither this package nor the handler imports ddd.
+type User struct {
+	Key  ids.ID
+	Name string
+}
+
+func (u *User) ID() ids.ID {
+	return u.Key
+}
+
+// Guest has a method of the same name with another signature.
+type Guest struct {
+	Name string
+}
+
+func (g *Guest) ID() string {
+	return g.Name
+}
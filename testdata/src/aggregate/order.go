@@ -0,0 +1,78 @@
+package aggregate
+
+type Order struct {
+	Lines    []*OrderLine
+	Shipping *ShippingInfo
+	Total    int
+}
+
+func (o *Order) AddLine(qty int) {
+	line := &OrderLine{}
+	line.SetQty(qty)
+	line.Price = 10
+	o.Lines = append(o.Lines, line)
+	o.Total += line.Subtotal()
+}
+
+func (o *Order) Ship(address string) {
+	o.Shipping.Address = address
+	o.Shipping.MarkShipped()
+}
+
+type OrderLine struct {
+	Qty   int
+	Price int
+}
+
+func (l *OrderLine) SetQty(qty int) { // want SetQty:"propro mutator"
+	l.Qty = qty
+}
+
+func (l *OrderLine) Subtotal() int {
+	return l.Qty * l.Price
+}
+
+func (l *OrderLine) Double() { // want Double:"propro mutator"
+	l.SetQty(l.Qty * 2)
+	l.Price++
+}
+
+// Restore mutates the line only through Clear, which mutates it through SetQty.
+func (l *OrderLine) Restore() { // want Restore:"propro mutator"
+	l.Clear()
+}
+
+func (l *OrderLine) Clear() { // want Clear:"propro mutator"
+	l.SetQty(0)
+}
+
+// ShippingInfo belongs to the Order aggregate.
+//
+//propro:aggregate Order
+type ShippingInfo struct { // want ShippingInfo:"propro directives"
+	Address string
+	shipped bool
+}
+
+func (s *ShippingInfo) MarkShipped() { // want MarkShipped:"propro mutator"
+	s.shipped = true
+}
+
+func (s *ShippingInfo) IsShipped() bool {
+	return s.shipped
+}
+
+func ChangeLine(o *Order) {
+	line := o.Lines[0]
+	line.SetQty(5) // want "call to mutating method OrderLine.SetQty is forbidden outside methods of its aggregate Order"
+	line.Qty = 5   // want "assignment to field OrderLine.Qty is forbidden outside methods of its aggregate Order"
+	_ = line.Subtotal()
+	o.Shipping.shipped = false // want "assignment to field ShippingInfo.shipped is forbidden outside methods of its aggregate Order"
+}
+
+// Coupon is listed under Order by the config, which takes precedence over the directive.
+//
+//propro:aggregate Cart
+type Coupon struct { // want Coupon:"propro directives" "struct Coupon is a member of several aggregates: Cart, Order"
+	Code string
+}
@@ -0,0 +1,13 @@
+package aggregateuse
+
+import "aggregate"
+
+func Handle(o *aggregate.Order) {
+	o.AddLine(1)
+	o.Lines[0].Double()            // want "call to mutating method OrderLine.Double is forbidden outside methods of its aggregate Order"
+	o.Lines[0].Restore()           // want "call to mutating method OrderLine.Restore is forbidden outside methods of its aggregate Order"
+	o.Shipping.MarkShipped()       // want "call to mutating method ShippingInfo.MarkShipped is forbidden outside methods of its aggregate Order"
+	o.Shipping.Address = "nowhere" // want "assignment to field ShippingInfo.Address is forbidden outside methods of its aggregate Order"
+	_ = o.Shipping.IsShipped()
+	o.Total = 0 // want "assignment to exported field Order.Total is forbidden outside its methods"
+}
@@ -0,0 +1,63 @@
+package callers
+
+type Order struct {
+	Lines  []*OrderLine
+	Closed bool
+}
+
+func (o *Order) Discount(percent int) {
+	for _, line := range o.Lines {
+		line.ApplyDiscount(percent)
+	}
+}
+
+func (o *Order) Close() {
+	o.Closed = true
+}
+
+type OrderLine struct {
+	Price int
+}
+
+// ApplyDiscount exists only for the Order aggregate root.
+//
+//propro:callers=Order
+func (l *OrderLine) ApplyDiscount(percent int) { // want ApplyDiscount:"propro directives"
+	l.Price -= l.Price * percent / 100
+}
+
+func (l *OrderLine) Halve() {
+	l.ApplyDiscount(50)
+}
+
+// Restore is used by persistence adapters within this package only.
+//
+//propro:internal
+func (l *OrderLine) Restore(price int) { // want Restore:"propro directives"
+	l.Price = price
+}
+
+//propro:internal
+func Rebuild(l *OrderLine) { // want Rebuild:"propro directives"
+	l.Restore(0)
+	l.ApplyDiscount(0) // want "call to OrderLine.ApplyDiscount is forbidden outside its permitted callers: Order"
+}
+
+// Reset is called by the Order aggregate root only.
+//
+//propro:callers=Order
+func Reset(l *OrderLine) { // want Reset:"propro directives"
+	l.Restore(0)
+}
+
+func (o *Order) Clear() {
+	for _, line := range o.Lines {
+		Reset(line)
+	}
+}
+
+func Clear(o *Order) {
+	Reset(o.Lines[0]) // want "call to Reset is forbidden outside its permitted callers: Order"
+	reset := Reset    // want "call to Reset is forbidden outside its permitted callers: Order"
+	reset(o.Lines[0])
+}
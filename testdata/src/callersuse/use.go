@@ -0,0 +1,20 @@
+package callersuse
+
+import "callers"
+
+type Service struct{}
+
+func (s *Service) Handle(o *callers.Order) {
+	o.Discount(10)
+	o.Lines[0].ApplyDiscount(10) // want "call to OrderLine.ApplyDiscount is forbidden outside its permitted callers: Order"
+	o.Lines[0].Restore(10)       // want "call to internal OrderLine.Restore is forbidden outside package callers"
+	callers.Rebuild(o.Lines[0])  // want "call to internal Rebuild is forbidden outside package callers"
+	o.Close()
+
+	apply := o.Lines[0].ApplyDiscount // want "call to OrderLine.ApplyDiscount is forbidden outside its permitted callers: Order"
+	apply(5)
+}
+
+func Close(o *callers.Order) {
+	o.Close() // want "call to Order.Close is forbidden outside its permitted callers: Service"
+}
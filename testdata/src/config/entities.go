@@ -1,8 +1,8 @@
 package config
 
-import "github.com/digitalstraw/propro/v2/testdata/src/protectall"
+import "protectselected"
 
 var EntityList = []any{
-	&protectall.Entity{},
-	&protectall.SubEntity{},
+	&protectselected.Entity{},
+	&protectselected.SubEntity{},
 }
@@ -1,6 +1,6 @@
 package config2
 
-import "github.com/digitalstraw/propro/v2/testdata/src/protectselected"
+import "protectselected"
 
 var EntityList = []any{
 	&protectselected.Entity{},
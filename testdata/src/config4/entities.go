@@ -0,0 +1,3 @@
+package config4
+
+var EntityList = []any{}
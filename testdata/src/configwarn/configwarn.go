@@ -0,0 +1,14 @@
+package configwarn // want "entity list file .*config4/entities.go yields no entries" "protected struct Missing matches no type" "protected struct other.Order matches no type"
+
+type Order struct {
+	Status string
+}
+
+type Empty struct { // want "protected struct Empty has no exported fields"
+	status string
+}
+
+func Pay(o *Order, e *Empty) {
+	o.Status = "paid" // want "assignment to exported field Order.Status is forbidden outside its methods"
+	e.status = "paid"
+}
@@ -0,0 +1,13 @@
+package ddd
+
+type Entity interface {
+	ID() string
+}
+
+type AggregateRoot struct {
+	events []any
+}
+
+func (r *AggregateRoot) Record(event any) {
+	r.events = append(r.events, event)
+}
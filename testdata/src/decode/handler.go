@@ -0,0 +1,51 @@
+package decode
+
+import (
+	"bytes"
+	"codec"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+
+	"github.com/jinzhu/copier"
+)
+
+type User struct {
+	Name string
+}
+
+type Request struct {
+	Name string
+}
+
+func Create(body io.Reader) (*User, error) {
+	var req Request
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		return nil, err
+	}
+
+	user := &User{}
+	if err := json.NewDecoder(body).Decode(user); err != nil { // want "decoding into protected struct User via \\(\\*encoding/json.Decoder\\).Decode is forbidden outside allowed packages"
+		return nil, err
+	}
+	_ = json.Unmarshal([]byte("{}"), &user) // want "decoding into protected struct User via encoding/json.Unmarshal is forbidden outside allowed packages"
+	_ = xml.Unmarshal([]byte("<a/>"), user) // want "decoding into protected struct User via encoding/xml.Unmarshal is forbidden outside allowed packages"
+	_ = gob.NewDecoder(body).Decode(user)   // want "decoding into protected struct User via \\(\\*encoding/gob.Decoder\\).Decode is forbidden outside allowed packages"
+	_ = copier.Copy(user, &req)             // want "decoding into protected struct User via github.com/jinzhu/copier.Copy is forbidden outside allowed packages"
+	_ = copier.Copy(&req, user)
+	_ = codec.Decode([]byte("{}"), user) // want "decoding into protected struct User via codec.Decode is forbidden outside allowed packages"
+	return user, nil
+}
+
+func List(data []byte) ([]*User, error) {
+	var users []*User
+	err := json.Unmarshal(data, &users) // want "decoding into protected struct User via encoding/json.Unmarshal is forbidden outside allowed packages"
+	return users, err
+}
+
+func Encode(u *User) ([]byte, error) {
+	var buf bytes.Buffer
+	err := json.NewEncoder(&buf).Encode(u)
+	return buf.Bytes(), err
+}
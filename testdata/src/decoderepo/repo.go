@@ -0,0 +1,12 @@
+package decoderepo
+
+import (
+	"decode"
+	"encoding/json"
+)
+
+func Load(data []byte) (*decode.User, error) {
+	u := &decode.User{}
+	err := json.Unmarshal(data, u)
+	return u, err
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"ddd"
+	"entitiesplain"
+)
+
+// Order embeds the aggregate root base type.
+type Order struct {
+	ddd.AggregateRoot
+	Status string
+}
+
+func (o *Order) Cancel() {
+	o.Status = "cancelled"
+}
+
+// Document embeds a pointer to the base type, Invoice embeds it through Document.
+type Document struct {
+	*ddd.AggregateRoot
+	Number string
+}
+
+type Invoice struct {
+	Document
+	Total int
+}
+
+// Customer implements ddd.Entity.
+type Customer struct {
+	Key  string
+	Name string
+}
+
+func (c *Customer) ID() string {
+	return c.Key
+}
+
+type Box[T any] struct {
+	ddd.AggregateRoot
+	Value T
+}
+
+// CustomerDTO is neither an entity nor an aggregate.
+type CustomerDTO struct {
+	Name string
+}
+
+func Update(o *Order, i *Invoice, c *Customer, b *Box[int], dto *CustomerDTO) {
+	o.Status = "paid"           // want "assignment to exported field Order.Status is forbidden outside its methods"
+	i.Total = 10                // want "assignment to exported field Invoice.Total is forbidden outside its methods"
+	i.Document.Number = "INV-1" // want "assignment to exported field Document.Number is forbidden outside its methods"
+	c.Name = "Jane"             // want "assignment to exported field Customer.Name is forbidden outside its methods"
+	b.Value = 1                 // want "assignment to exported field Box.Value is forbidden outside its methods"
+	dto.Name = "Jane"
+}
+
+func Rename(s *entitiesplain.Supplier) {
+	s.Name = "Acme" // want "assignment to exported field Supplier.Name is forbidden outside its methods"
+}
@@ -0,0 +1,11 @@
+package entitiesplain
+
+// Supplier implements ddd.Entity without importing ddd.
+type Supplier struct {
+	Key  string
+	Name string
+}
+
+func (s Supplier) ID() string {
+	return s.Key
+}
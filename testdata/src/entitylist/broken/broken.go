@@ -0,0 +1,7 @@
+package broken
+
+import "github.com/digitalstraw/propro/v2/testdata/src/entitylist/users"
+
+var entity any = &users.User{}
+
+var EntityList = []any{entity}
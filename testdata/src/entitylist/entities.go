@@ -0,0 +1,14 @@
+package entitylist
+
+import (
+	"github.com/digitalstraw/propro/v2/testdata/src/entitylist/orders"
+	"github.com/digitalstraw/propro/v2/testdata/src/entitylist/users"
+)
+
+type Audit struct {
+	By string
+}
+
+var Models = append(users.Entities, orders.Entities()...)
+
+var Extra = append([]any{}, &Audit{})
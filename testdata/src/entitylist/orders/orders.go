@@ -0,0 +1,14 @@
+package orders
+
+type Order struct {
+	Status string
+}
+
+type Line struct {
+	Qty int
+}
+
+func Entities() []any {
+	entities := []any{Order{}}
+	return append(entities, &Line{})
+}
@@ -0,0 +1,15 @@
+package users
+
+type User struct {
+	Name string
+}
+
+type Profile struct {
+	Bio string
+}
+
+type Box[T any] struct {
+	Value T
+}
+
+var Entities = []any{&User{}, new(Profile), &Box[int]{}}
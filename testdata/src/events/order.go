@@ -0,0 +1,77 @@
+package events
+
+type Event struct {
+	Name string
+}
+
+type Order struct {
+	Status string
+	Notes  string
+	events []Event
+}
+
+func (o *Order) Record(e Event) {
+	o.events = append(o.events, e)
+}
+
+func (o *Order) Submit() {
+	o.Status = "submitted"
+	o.Record(Event{Name: "submitted"})
+}
+
+func (o *Order) Cancel(reason string) { // want "method Order.Cancel writes protected fields without recording a domain event on some path"
+	if reason == "" {
+		o.Status = "cancelled"
+		return
+	}
+	o.Status = "cancelled"
+	o.Notes = reason
+	o.Record(Event{Name: "cancelled"})
+}
+
+func (o *Order) Approve(ok bool) {
+	if !ok {
+		panic("not approved")
+	}
+	defer o.Record(Event{Name: "approved"})
+	o.Status = "approved"
+}
+
+func (o *Order) Ship() {
+	o.Status = "shipped"
+	o.events = append(o.events, Event{Name: "shipped"})
+}
+
+func (o *Order) Annotate(notes []string) { // want "method Order.Annotate writes protected fields without recording a domain event on some path"
+	for _, n := range notes {
+		o.Notes += n
+	}
+}
+
+// Import hydrates the order from storage.
+//
+//propro:no-event hydration is not a domain change
+func (o *Order) Import(status string) { // want Import:"propro directives"
+	o.Status = status
+}
+
+//propro:no-event
+func (o *Order) Touch() { // want Touch:"propro directives" "//propro:no-event directive of method Order.Touch requires a justification"
+	o.Notes = ""
+}
+
+func (o *Order) Summary() string {
+	return o.Status + o.Notes
+}
+
+func (o *Order) reset() {
+	o.Status = ""
+}
+
+type Customer struct {
+	Name string
+}
+
+func (c *Customer) Rename(name string) {
+	c.Name = name
+}
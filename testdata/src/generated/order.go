@@ -0,0 +1,15 @@
+package generated
+
+type Order struct {
+	Status string
+}
+
+func Load(status string) *Order {
+	order := &Order{}
+	order.hydrate(status) // generated method of Order
+	return order
+}
+
+func Reset(order *Order) {
+	order.Status = "new" // want "assignment to exported field Order.Status is forbidden outside its methods"
+}
@@ -0,0 +1,5 @@
+package generatedby
+
+type Order struct {
+	Status string
+}
@@ -0,0 +1,6 @@
+// Package copier is a minimal stub of github.com/jinzhu/copier for tests.
+package copier
+
+func Copy(toValue any, fromValue any) error {
+	return nil
+}
@@ -0,0 +1,13 @@
+// Package gorm is a minimal stub of gorm.io/gorm for tests.
+package gorm
+
+type DB struct{}
+
+func (db *DB) Model(value any) *DB                       { return db }
+func (db *DB) Where(query any, args ...any) *DB          { return db }
+func (db *DB) Update(column string, value any) *DB       { return db }
+func (db *DB) UpdateColumn(column string, value any) *DB { return db }
+func (db *DB) Updates(values any) *DB                    { return db }
+func (db *DB) UpdateColumns(values any) *DB              { return db }
+func (db *DB) Save(value any) *DB                        { return db }
+func (db *DB) AutoMigrate(dst ...any) error              { return nil }
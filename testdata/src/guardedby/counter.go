@@ -0,0 +1,74 @@
+package guardedby
+
+import "sync"
+
+type Counter struct {
+	mu sync.Mutex
+
+	//propro:guardedby mu
+	Count int // want Count:"propro directives"
+
+	Last  string
+	Total int
+}
+
+func (c *Counter) Inc() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Count++
+	c.Last = "inc"
+}
+
+func (c *Counter) Add(n int) {
+	c.mu.Lock()
+	c.Count += n
+	c.mu.Unlock()
+	c.Count -= n // want "assignment to field Counter.Count requires mu.Lock\\(\\) to be held"
+}
+
+func (c *Counter) Reset(hard bool) {
+	if hard {
+		c.mu.Lock()
+	}
+	c.Count = 0 // want "assignment to field Counter.Count requires mu.Lock\\(\\) to be held"
+	if hard {
+		c.mu.Unlock()
+	}
+}
+
+func (c *Counter) Set(n int) {
+	c.mu.Lock()
+	for i := 0; i < n; i++ {
+		c.Count = i
+	}
+	c.mu.Unlock()
+}
+
+func (c *Counter) Async() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	go func() {
+		c.Count = 1 // want "assignment to field Counter.Count requires mu.Lock\\(\\) to be held"
+	}()
+	go func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.Count = 2
+	}()
+}
+
+func (c *Counter) Record(name string) {
+	c.Last = name // want "assignment to field Counter.Last requires mu.Lock\\(\\) to be held"
+	c.Total++
+}
+
+func Bump(c *Counter) {
+	c.mu.Lock()
+	c.Count++ // want "assignment to exported field Counter.Count is forbidden outside its methods"
+	c.mu.Unlock()
+}
+
+// Other is the only struct protected by name, so that the guarded fields are checked on their own.
+type Other struct {
+	Name string
+}
@@ -0,0 +1,31 @@
+package hidden
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type User struct {
+	Name         string
+	PasswordHash string `gorm:"column:password_hash" propro:"hidden"`
+	APIKey       string
+
+	//propro:hidden
+	TOTPSecret string // want TOTPSecret:"propro directives"
+}
+
+func (u *User) CheckPassword(hash string) bool {
+	return u.PasswordHash == hash
+}
+
+func (u *User) Debug() {
+	fmt.Println(u.Name)
+	fmt.Println(u.PasswordHash)        // want "hidden field User.PasswordHash must not be passed to fmt.Println"
+	slog.Info("user", "key", u.APIKey) // want "hidden field User.APIKey must not be passed to log/slog.Info"
+}
+
+func Leak(u *User) string {
+	fmt.Printf("%v", u)      // want "User with hidden field PasswordHash must not be passed to fmt.Printf"
+	_ = len(u.TOTPSecret)    // want "read of hidden field User.TOTPSecret is forbidden outside its methods"
+	return u.Name + u.APIKey // want "read of hidden field User.APIKey is forbidden outside its methods"
+}
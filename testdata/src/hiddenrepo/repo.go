@@ -0,0 +1,14 @@
+package hiddenrepo
+
+import (
+	"fmt"
+	"hidden"
+)
+
+func Columns(u *hidden.User) []any {
+	return []any{u.Name, u.PasswordHash, u.TOTPSecret, u.APIKey}
+}
+
+func Dump(u hidden.User) {
+	fmt.Println(u) // want "User with hidden field PasswordHash must not be passed to fmt.Println"
+}
@@ -0,0 +1,16 @@
+package hiddenuse
+
+import (
+	"hidden"
+	"log"
+)
+
+func Handle(u *hidden.User) string {
+	log.Print(u.Name)
+	log.Print(u.PasswordHash) // want "hidden field User.PasswordHash must not be passed to log.Print"
+	u.PasswordHash = ""       // want "assignment to exported field User.PasswordHash is forbidden outside its methods"
+	if u.CheckPassword("x") {
+		return u.TOTPSecret // want "read of hidden field User.TOTPSecret is forbidden outside its methods"
+	}
+	return u.PasswordHash // want "read of hidden field User.PasswordHash is forbidden outside its methods"
+}
@@ -0,0 +1,62 @@
+package immutable
+
+type Account struct {
+	//propro:immutable
+	ID string // want ID:"propro directives"
+
+	TenantID  string
+	CreatedAt int
+	Name      string
+}
+
+//propro:constructor
+func RestoreAccount(a *Account, id string) { // want RestoreAccount:"propro directives"
+	a.ID = id
+	a.TenantID = "tenant"
+}
+
+func NewAccount(id string) *Account {
+	a := &Account{}
+	a.ID = id
+	a.TenantID = "tenant"
+	return a
+}
+
+func NewValueAccount(id string) Account {
+	var a Account
+	a.ID = id
+	return a
+}
+
+func NewLateAccount(id string) *Account {
+	a := new(Account)
+	a.Rename("late")
+	a.ID = id // want "assignment to immutable field Account.ID is forbidden after construction"
+	return a
+}
+
+func Hydrate(a *Account, id string) {
+	a.ID = id // want "assignment to immutable field Account.ID is forbidden after construction"
+}
+
+func (a *Account) Rename(name string) {
+	a.Name = name
+	a.ID = name      // want "assignment to immutable field Account.ID is forbidden after construction"
+	a.CreatedAt++    // want "assignment to immutable field Account.CreatedAt is forbidden after construction"
+	p := &a.TenantID // want "assignment to immutable field Account.TenantID is forbidden after construction"
+	*p = name
+}
+
+func (a *Account) Reset(other Account) {
+	*a = other // want "assignment to immutable field Account.ID is forbidden after construction" "assignment to immutable field Account.TenantID is forbidden after construction" "assignment to immutable field Account.CreatedAt is forbidden after construction"
+}
+
+func (a *Account) Clone() *Account {
+	c := &Account{}
+	*c = *a
+	return c
+}
+
+func Rebuild(a *Account) {
+	*a = Account{} // want "assignment to immutable field Account.ID is forbidden after construction" "assignment to immutable field Account.TenantID is forbidden after construction" "assignment to immutable field Account.CreatedAt is forbidden after construction"
+}
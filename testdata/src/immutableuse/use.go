@@ -0,0 +1,15 @@
+package immutableuse
+
+import "immutable"
+
+// NewAccount is not the configured constructor of immutable.Account.
+func NewAccount(a *immutable.Account, tenantID string) {
+	a.TenantID = tenantID // want "assignment to immutable field Account.TenantID is forbidden after construction"
+}
+
+// Open constructs an account outside its package, which is not an immutable field violation but a protected one.
+func Open(id string) *immutable.Account {
+	a := &immutable.Account{}
+	a.ID = id // want "assignment to exported field Account.ID is forbidden outside its methods"
+	return a
+}
@@ -0,0 +1,92 @@
+package invariants
+
+import "errors"
+
+var errInvalid = errors.New("invalid")
+
+type Order struct {
+	Name  string
+	Total int
+	Lines []int
+	calls int
+}
+
+func (o *Order) Validate() error {
+	if o.Total < 0 {
+		return errInvalid
+	}
+	return nil
+}
+
+func (o *Order) Rename(name string) error {
+	o.Name = name
+	return o.Validate()
+}
+
+func (o *Order) AddLine(price int) error {
+	defer o.Validate()
+	o.Lines = append(o.Lines, price)
+	o.Total += price
+	return nil
+}
+
+func (o *Order) SetTotal(total int) { // want "method Order.SetTotal writes Order.Total without calling Validate before returning"
+	o.Total = total
+}
+
+func (o *Order) Update(name string, total int) error { // want "method Order.Update writes Order.Name, Order.Total without calling Validate before returning"
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	o.Name = name
+	if total > 0 {
+		o.Total = total
+		return nil
+	}
+	return o.Validate()
+}
+
+func (o *Order) Replace(other Order) { // want "method Order.Replace writes Order.\\* without calling Validate before returning"
+	*o = other
+}
+
+func (o *Order) count() {
+	o.calls++
+}
+
+type Customer struct {
+	Name string
+}
+
+// Check verifies invariants of the customer.
+//
+//propro:invariant
+func (c *Customer) Check() bool { // want Check:"propro directives"
+	return c.Name != ""
+}
+
+func (c *Customer) Rename(name string) { // want "method Customer.Rename writes Customer.Name without calling Check before returning"
+	c.Name = name
+	if name == "" {
+		panic("empty name")
+	}
+}
+
+func (c *Customer) SafeRename(name string) {
+	c.Name = name
+	if !c.Check() {
+		panic("invalid customer")
+	}
+}
+
+type Unprotected struct {
+	Name string
+}
+
+func (u *Unprotected) Validate() error {
+	return nil
+}
+
+func (u *Unprotected) Rename(name string) {
+	u.Name = name
+}
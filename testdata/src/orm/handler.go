@@ -0,0 +1,50 @@
+package orm
+
+import "gorm.io/gorm"
+
+type User struct {
+	ID       int
+	Email    string `gorm:"column:email_address"`
+	Status   string
+	APIKey   string
+	LastName string
+}
+
+type AuditLog struct {
+	Message string
+}
+
+const statusColumn = "status"
+
+func Ban(db *gorm.DB, u *User) {
+	db.Model(u).Update("status", "banned")                                    // want "ORM update of User.Status via Update bypasses its methods"
+	db.Model(&User{}).Where("id = ?", u.ID).UpdateColumn("users.status", "x") // want "ORM update of User.Status via UpdateColumn bypasses its methods"
+	db.Model(u).Update(statusColumn+" = ?", "banned")                         // want "ORM update of User.Status via Update bypasses its methods"
+	db.Model(u).Updates(map[string]any{
+		"email_address": "x", // want "ORM update of User.Email via Updates bypasses its methods"
+		"api_key":       "y", // want "ORM update of User.APIKey via Updates bypasses its methods"
+		"last_name":     "z", // want "ORM update of User.LastName via Updates bypasses its methods"
+		"nickname":      "w", // want "ORM update of column nickname of User via Updates bypasses its methods"
+	})
+	db.Model(u).Updates(User{Status: "banned"}) // want "ORM update of User.Status via Updates bypasses its methods"
+
+	values := map[string]any{"status": "x"}
+	db.Model(u).UpdateColumns(values) // want "ORM update of User via UpdateColumns bypasses its methods"
+
+	db.Model(&AuditLog{}).Update("message", "x")
+	db.Where(u).Model(&AuditLog{}).Update("message", "x")
+	db.Model(&AuditLog{}).Where(u).Update("message", "x")
+	db.Model(u).Where(&AuditLog{}).Update("status", "x") // want "ORM update of User.Status via Update bypasses its methods"
+	db.Save(u)
+}
+
+// Query is a query builder of another ORM, configured by ormUpdates and ormModels.
+type Query struct{}
+
+func (q *Query) For(model any) *Query            { return q }
+func (q *Query) Set(column string, v any) *Query { return q }
+
+func Activate(q *Query, u *User, log *AuditLog) {
+	q.For(u).Set("status", "active") // want "ORM update of User.Status via Set bypasses its methods"
+	q.For(log).Set("message", u)
+}
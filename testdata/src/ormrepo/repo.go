@@ -0,0 +1,10 @@
+package ormrepo
+
+import (
+	"gorm.io/gorm"
+	"orm"
+)
+
+func Activate(db *gorm.DB, u *orm.User) {
+	db.Model(u).Update("status", "active")
+}
@@ -0,0 +1,16 @@
+package admin
+
+import "qualified/users"
+
+// User shares the name of the protected users.User.
+type User struct {
+	Name string
+}
+
+func Rename(u *User) {
+	u.Name = "renamed"
+}
+
+func Promote(u *users.User) *User {
+	return &User{Name: u.Name}
+}
@@ -0,0 +1,7 @@
+package entities
+
+import "qualified/users"
+
+var EntityList = []any{
+	&users.User{},
+}
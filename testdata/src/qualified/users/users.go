@@ -0,0 +1,9 @@
+package users
+
+type User struct {
+	Name string
+}
+
+func Rename(u *User) {
+	u.Name = "renamed" // want "assignment to exported field User.Name is forbidden outside its methods"
+}
@@ -0,0 +1,33 @@
+package registration // want package:"propro registered entities registration.Customer, registration.Order"
+
+import "gorm.io/gorm"
+
+type Order struct {
+	Status string
+}
+
+func (o *Order) Cancel() {
+	o.Status = "cancelled"
+}
+
+type Customer struct {
+	Name string
+}
+
+// Note is not registered, thus not protected.
+type Note struct {
+	Text string
+}
+
+func Register(entities ...any) {}
+
+func Migrate(db *gorm.DB) {
+	Register(&Order{})
+	_ = db.AutoMigrate([]any{new(Customer)}...)
+}
+
+func Update(o *Order, c *Customer, n *Note) {
+	o.Status = "paid" // want "assignment to exported field Order.Status is forbidden outside its methods"
+	c.Name = "Jane"   // want "assignment to exported field Customer.Name is forbidden outside its methods"
+	n.Text = "hello"
+}
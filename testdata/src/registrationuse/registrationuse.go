@@ -0,0 +1,8 @@
+package registrationuse
+
+import "registration"
+
+func Pay(o *registration.Order, n *registration.Note) {
+	o.Status = "paid" // want "assignment to exported field Order.Status is forbidden outside its methods"
+	n.Text = "paid"
+}
@@ -0,0 +1,5 @@
+package api
+
+type Order struct {
+	Status string
+}
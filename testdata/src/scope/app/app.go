@@ -0,0 +1,37 @@
+package app
+
+import (
+	"scope/api"
+	"scope/domain"
+	"scope/domain/sub"
+)
+
+type Local struct {
+	Name string
+}
+
+func Update() {
+	order := &domain.Order{}
+	order.Status = "paid" // want "assignment to exported field Order.Status is forbidden outside its methods"
+
+	model := &domain.Model{}
+	model.Status = "paid" // want "assignment to exported field Model.Status is forbidden outside its methods"
+
+	item := &sub.Item{}
+	item.Name = "book" // want "assignment to exported field Item.Name is forbidden outside its methods"
+
+	fixture := &domain.OrderFixture{}
+	fixture.Status = "paid" // excluded by pattern
+
+	req := &domain.CreateOrderRequest{}
+	req.Status = "paid" // DTO by name
+
+	payload := &domain.Payload{}
+	payload.Status = "paid" // DTO by json tags only
+
+	apiOrder := &api.Order{}
+	apiOrder.Status = "paid" // outside of protected packages
+
+	local := &Local{}
+	local.Name = "local"
+}
@@ -0,0 +1,21 @@
+package domain
+
+type Order struct {
+	Status string
+}
+
+type OrderFixture struct {
+	Status string
+}
+
+type CreateOrderRequest struct {
+	Status string
+}
+
+type Payload struct {
+	Status string `json:"status"`
+}
+
+type Model struct {
+	Status string `json:"status" gorm:"column:status"`
+}
@@ -0,0 +1,8 @@
+//propro:ignore-file legacy code to be removed
+
+package suppress
+
+func Legacy(o *Order) {
+	o.Status = "legacy"
+	o.Total = 0
+}
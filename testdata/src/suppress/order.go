@@ -0,0 +1,37 @@
+package suppress
+
+type Order struct {
+	Status string
+	Total  int
+}
+
+func Trailing(o *Order) {
+	o.Status = "imported" //propro:ignore migrated by the legacy importer
+	o.Total = 1           // want "assignment to exported field Order.Total is forbidden outside its methods"
+}
+
+func Statement(o *Order) {
+	//propro:ignore reset by the admin tool
+	if o != nil {
+		o.Status = ""
+		o.Total = 0
+	}
+	o.Status = "new" // want "assignment to exported field Order.Status is forbidden outside its methods"
+}
+
+func Duplicate(o *Order) {
+	o.Total++ //propro:ignore counted by the importer
+}
+
+func Unused(o *Order) {
+	//propro:ignore no longer needed // want "unused //propro:ignore directive"
+	o.Pay()
+}
+
+func (o *Order) Pay() {
+	o.Status = "paid"
+}
+
+type Draft struct { //propro:ignore drafts are listed for the entity registry, they have no exported fields yet
+	note string
+}
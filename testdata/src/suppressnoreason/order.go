@@ -0,0 +1,10 @@
+package suppressnoreason
+
+type Order struct {
+	Status string
+}
+
+func Reset(o *Order) {
+	// The expectations are in a block comment, as a trailing line comment would be the reason of the directive.
+	o.Status = "" /* want "//propro:ignore directive requires a reason" "assignment to exported field Order.Status is forbidden outside its methods" */ //propro:ignore
+}
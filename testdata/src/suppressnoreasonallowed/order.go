@@ -0,0 +1,9 @@
+package suppressnoreasonallowed
+
+type Order struct {
+	Status string
+}
+
+func Reset(o *Order) {
+	o.Status = "" //propro:ignore
+}
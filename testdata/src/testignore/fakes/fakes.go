@@ -0,0 +1,15 @@
+package fakes
+
+import (
+	"testing"
+
+	"testignore"
+)
+
+// Test helper package importing testing, whose name does not end with test.
+func PaidOrder(t testing.TB) *testignore.Order {
+	t.Helper()
+	order := &testignore.Order{}
+	order.Status = "paid"
+	return order
+}
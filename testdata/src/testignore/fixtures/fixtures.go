@@ -0,0 +1,9 @@
+package fixtures
+
+import "testignore"
+
+func PaidOrder() *testignore.Order {
+	order := &testignore.Order{}
+	order.Status = "paid"
+	return order
+}
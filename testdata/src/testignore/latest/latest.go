@@ -0,0 +1,8 @@
+package latest
+
+import "testignore"
+
+// Production package whose name ends with test.
+func Reset(order *testignore.Order) {
+	order.Status = "latest" // want "assignment to exported field Order.Status is forbidden outside its methods"
+}
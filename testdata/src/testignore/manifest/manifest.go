@@ -0,0 +1,7 @@
+package manifest
+
+import "testignore"
+
+func Reset(order *testignore.Order) {
+	order.Status = "manifest" // want "assignment to exported field Order.Status is forbidden outside its methods"
+}
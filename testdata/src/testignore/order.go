@@ -0,0 +1,9 @@
+package testignore
+
+type Order struct {
+	Status string
+}
+
+func Reset(order *Order) {
+	order.Status = "new" // want "assignment to exported field Order.Status is forbidden outside its methods"
+}
@@ -0,0 +1,9 @@
+package testignore
+
+import "testing"
+
+func TestReset(t *testing.T) {
+	order := &Order{Status: "paid"}
+	order.Status = "paid"
+	Reset(order)
+}
@@ -0,0 +1,14 @@
+package ordertest
+
+import (
+	"testing"
+
+	"testignore"
+)
+
+func NewOrder(t *testing.T) *testignore.Order {
+	t.Helper()
+	order := &testignore.Order{}
+	order.Status = "new"
+	return order
+}
@@ -0,0 +1,11 @@
+package app
+
+import (
+	"testpolicy"
+	"testpolicy/ordertest" // want "production code imports test builders of package testpolicy/ordertest"
+)
+
+func Reset(order *testpolicy.Order) {
+	order.Status = "new" // want "assignment to exported field Order.Status is forbidden outside its methods"
+	_ = ordertest.OrderBuilder{}
+}
@@ -0,0 +1,9 @@
+package testpolicy
+
+type Order struct {
+	Status string
+}
+
+func (o *Order) Pay() {
+	o.Status = "paid"
+}
@@ -0,0 +1,24 @@
+package testpolicy
+
+import "testing"
+
+type OrderBuilder struct {
+	order *Order
+}
+
+func (b *OrderBuilder) WithStatus(status string) *OrderBuilder {
+	b.order.Status = status
+	return b
+}
+
+func givenPaidOrder() *Order {
+	order := &Order{}
+	order.Status = "paid"
+	return order
+}
+
+func TestPay(t *testing.T) {
+	order := givenPaidOrder()
+	order.Status = "new" // want "assignment to exported field Order.Status is forbidden outside its methods"
+	order.Pay()
+}
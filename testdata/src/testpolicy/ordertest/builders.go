@@ -0,0 +1,25 @@
+package ordertest
+
+import (
+	"testing"
+
+	"testpolicy"
+)
+
+type OrderBuilder struct {
+	order *testpolicy.Order
+}
+
+func (b *OrderBuilder) WithStatus(status string) *OrderBuilder {
+	b.order.Status = status
+	return b
+}
+
+func (b *OrderBuilder) Build(t *testing.T) *testpolicy.Order {
+	t.Helper()
+	return b.order
+}
+
+func Cancel(order *testpolicy.Order) {
+	order.Status = "cancelled" // want "assignment to exported field Order.Status is forbidden outside its methods"
+}
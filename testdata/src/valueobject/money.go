@@ -0,0 +1,70 @@
+package valueobject
+
+type Money struct {
+	Amount   int
+	Currency string
+}
+
+func NewMoney(amount int, currency string) Money {
+	m := Money{}
+	m.Amount = amount
+	m.Currency = currency
+	return m
+}
+
+func (m Money) Add(other Money) Money {
+	m.Amount += other.Amount
+	return m
+}
+
+func (m *Money) Double() { // want "value object Money should not have pointer receiver method Double; return a new value instead"
+	m.Amount *= 2 // want "assignment to field Money.Amount of value object is forbidden outside constructors returning Money"
+}
+
+func (m *Money) Reset() *Money { // want "value object Money should not have pointer receiver method Reset; return a new value instead"
+	*m = Money{} // want "assignment to value object Money is forbidden outside constructors returning Money"
+	return m
+}
+
+func (m Money) Print() {
+	m.Currency = "EUR" // want "assignment to field Money.Currency of value object is forbidden outside constructors returning Money"
+}
+
+func Spend(m *Money, amount int) {
+	m.Amount -= amount // want "assignment to field Money.Amount of value object is forbidden outside constructors returning Money"
+}
+
+// Period is a value object declared by directive.
+//
+//propro:value-object
+type Period struct { // want Period:"propro directives"
+	From int
+	To   int
+}
+
+func NewPeriod(from, to int) *Period {
+	p := &Period{}
+	p.From, p.To = from, to
+	return p
+}
+
+func (p Period) Extend(days int) Period {
+	p.To += days
+	return p
+}
+
+func (p *Period) Shift(days int) { // want "value object Period should not have pointer receiver method Shift; return a new value instead"
+	p.From += days // want "assignment to field Period.From of value object is forbidden outside constructors returning Period"
+	p.To += days   // want "assignment to field Period.To of value object is forbidden outside constructors returning Period"
+}
+
+func Clamp(p *Period, maxTo int) Period {
+	p.To = maxTo // want "assignment to field Period.To of value object is forbidden outside constructors returning Period"
+	return *p
+}
+
+func Normalized(p Period) (out Period) {
+	out = p
+	out.From = 0
+	return out
+}
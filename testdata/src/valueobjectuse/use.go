@@ -0,0 +1,17 @@
+package valueobjectuse
+
+import "valueobject"
+
+func Stretch(p valueobject.Period) valueobject.Period {
+	p.To++ // want "assignment to field Period.To of value object is forbidden outside constructors returning Period"
+	return p
+}
+
+func Tamper(p *valueobject.Period) {
+	p.From = 0 // want "assignment to field Period.From of value object is forbidden outside constructors returning Period"
+}
+
+func Rebase(p *valueobject.Period) valueobject.Period {
+	p.From = 0 // want "assignment to field Period.From of value object is forbidden outside constructors returning Period"
+	return *p
+}
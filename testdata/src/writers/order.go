@@ -0,0 +1,68 @@
+package writers
+
+type Order struct {
+	// Status may only change through state transitions.
+	//propro:writers Submit, Approve, Cancel, Ship
+	Status string // want Status:"propro directives"
+
+	Name  string
+	Total int
+}
+
+func NewOrder(name string) *Order {
+	o := &Order{}
+	o.Name = name    // want "assignment to exported field Order.Name is forbidden outside its methods"
+	o.Status = "new" // want "assignment to field Order.Status is forbidden outside its permitted writers: Submit, Approve, Cancel, Ship"
+	return o
+}
+
+func (o *Order) Submit() {
+	o.Status = "submitted"
+}
+
+func (o *Order) Approve() {
+	o.Status = "approved"
+}
+
+func (o *Order) Cancel() {
+	func() {
+		o.Status = "cancelled"
+	}()
+}
+
+func (o *Order) Ship() {
+	o.Status = "shipped"
+}
+
+func (o *Order) Rename(name string) {
+	o.Name = name
+	o.Status = "renamed" // want "assignment to field Order.Status is forbidden outside its permitted writers: Submit, Approve, Cancel, Ship"
+}
+
+func (o *Order) SetTotal(total int) {
+	o.Total = total
+}
+
+type Invoice struct {
+	// Total is recalculated only by its order.
+	Total int
+}
+
+func (i *Invoice) Recalculate(o *Order) {
+	i.Total = o.Total // want "assignment to field Invoice.Total is forbidden outside its permitted writers: Order.Recalculate, Reset, writersuse.Settle"
+}
+
+func (o *Order) Recalculate(i *Invoice) {
+	i.Total = o.Total
+}
+
+func Reset(i *Invoice) {
+	i.Total = 0
+	p := &i.Total
+	*p = 0
+}
+
+func Tamper(i *Invoice) {
+	p := &i.Total // want "assignment to field Invoice.Total is forbidden outside its permitted writers: Order.Recalculate, Reset, writersuse.Settle"
+	*p = 1
+}
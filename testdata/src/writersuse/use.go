@@ -0,0 +1,23 @@
+package writersuse
+
+import "writers"
+
+func Force(o *writers.Order, i *writers.Invoice) {
+	o.Status = "shipped" // want "assignment to field Order.Status is forbidden outside its permitted writers: Submit, Approve, Cancel, Ship"
+	o.Name = "forced"    // want "assignment to exported field Order.Name is forbidden outside its methods"
+	o.Submit()
+	i.Total = 0 // want "assignment to field Invoice.Total is forbidden outside its permitted writers: Order.Recalculate, Reset, writersuse.Settle"
+}
+
+// Submit is not the Order.Submit writer, as unqualified writers are declared in the package of the field.
+func Submit(o *writers.Order) {
+	o.Status = "submitted" // want "assignment to field Order.Status is forbidden outside its permitted writers: Submit, Approve, Cancel, Ship"
+}
+
+func Reset(i *writers.Invoice) {
+	i.Total = 0 // want "assignment to field Invoice.Total is forbidden outside its permitted writers: Order.Recalculate, Reset, writersuse.Settle"
+}
+
+func Settle(i *writers.Invoice) {
+	i.Total = 0
+}